@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "github.com/n3wscott/theme-park-provider/api/v1alpha1"
+
+// ToCluster returns the cluster-scoped Ride this Ride is managed as. It keeps
+// the namespace of this Ride, and writes its connection details to a Secret
+// in that namespace. Its ProviderConfig reference still refers to a
+// namespaced ProviderConfig in that namespace.
+func (mg *Ride) ToCluster() *v1alpha1.Ride {
+	r := &v1alpha1.Ride{
+		ObjectMeta: *mg.ObjectMeta.DeepCopy(),
+		Spec: v1alpha1.RideSpec{
+			ForProvider: v1alpha1.RideParameters(*mg.Spec.ForProvider.DeepCopy()),
+		},
+		Status: *mg.Status.DeepCopy(),
+	}
+	r.SetGroupVersionKind(v1alpha1.RideGroupVersionKind)
+	r.SetDeletionPolicy(mg.GetDeletionPolicy())
+	r.SetManagementPolicies(mg.GetManagementPolicies())
+	r.SetProviderConfigReference(mg.GetProviderConfigReference())
+	r.SetWriteConnectionSecretToReference(mg.GetWriteConnectionSecretToReference())
+	return r
+}
+
+// FromCluster updates this Ride with the metadata, parameters and status of
+// the supplied cluster-scoped Ride.
+func (mg *Ride) FromCluster(r *v1alpha1.Ride) {
+	mg.ObjectMeta = *r.ObjectMeta.DeepCopy()
+	mg.Spec.ForProvider = RideParameters(*r.Spec.ForProvider.DeepCopy())
+	mg.Status = *r.Status.DeepCopy()
+}
+
+// ToCluster returns the cluster-scoped RideOperator this RideOperator is
+// managed as. It keeps the namespace of this RideOperator, and writes its
+// connection details to a Secret in that namespace. Its ProviderConfig
+// reference still refers to a namespaced ProviderConfig in that namespace.
+func (mg *RideOperator) ToCluster() *v1alpha1.RideOperator {
+	ro := &v1alpha1.RideOperator{
+		ObjectMeta: *mg.ObjectMeta.DeepCopy(),
+		Spec: v1alpha1.RideOperatorSpec{
+			ForProvider: v1alpha1.RideOperatorParameters(*mg.Spec.ForProvider.DeepCopy()),
+		},
+		Status: *mg.Status.DeepCopy(),
+	}
+	ro.SetGroupVersionKind(v1alpha1.RideOperatorGroupVersionKind)
+	ro.SetDeletionPolicy(mg.GetDeletionPolicy())
+	ro.SetManagementPolicies(mg.GetManagementPolicies())
+	ro.SetProviderConfigReference(mg.GetProviderConfigReference())
+	ro.SetWriteConnectionSecretToReference(mg.GetWriteConnectionSecretToReference())
+	return ro
+}
+
+// FromCluster updates this RideOperator with the metadata, parameters and
+// status of the supplied cluster-scoped RideOperator.
+func (mg *RideOperator) FromCluster(ro *v1alpha1.RideOperator) {
+	mg.ObjectMeta = *ro.ObjectMeta.DeepCopy()
+	mg.Spec.ForProvider = RideOperatorParameters(*ro.Spec.ForProvider.DeepCopy())
+	mg.Status = *ro.Status.DeepCopy()
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// These accessors follow the ones angryjet generates, but are written by hand
+// because a namespaced ResourceSpec only references a Secret by name.
+
+var (
+	_ resource.Managed = (*Ride)(nil)
+	_ resource.Managed = (*RideOperator)(nil)
+)
+
+// GetCondition of this Ride.
+func (mg *Ride) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// GetDeletionPolicy of this Ride.
+func (mg *Ride) GetDeletionPolicy() xpv1.DeletionPolicy {
+	return mg.Spec.DeletionPolicy
+}
+
+// GetManagementPolicies of this Ride.
+func (mg *Ride) GetManagementPolicies() xpv1.ManagementPolicies {
+	return mg.Spec.ManagementPolicies
+}
+
+// GetProviderConfigReference of this Ride.
+func (mg *Ride) GetProviderConfigReference() *xpv1.Reference {
+	return mg.Spec.ProviderConfigReference
+}
+
+// GetPublishConnectionDetailsTo of this Ride. Namespaced resources can't
+// publish connection details to a secret store.
+func (mg *Ride) GetPublishConnectionDetailsTo() *xpv1.PublishConnectionDetailsTo {
+	return nil
+}
+
+// GetWriteConnectionSecretToReference of this Ride. The Secret is always in
+// the namespace of the Ride.
+func (mg *Ride) GetWriteConnectionSecretToReference() *xpv1.SecretReference {
+	if mg.Spec.WriteConnectionSecretToReference == nil {
+		return nil
+	}
+	return &xpv1.SecretReference{Name: mg.Spec.WriteConnectionSecretToReference.Name, Namespace: mg.GetNamespace()}
+}
+
+// SetConditions of this Ride.
+func (mg *Ride) SetConditions(c ...xpv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetDeletionPolicy of this Ride.
+func (mg *Ride) SetDeletionPolicy(r xpv1.DeletionPolicy) {
+	mg.Spec.DeletionPolicy = r
+}
+
+// SetManagementPolicies of this Ride.
+func (mg *Ride) SetManagementPolicies(r xpv1.ManagementPolicies) {
+	mg.Spec.ManagementPolicies = r
+}
+
+// SetProviderConfigReference of this Ride.
+func (mg *Ride) SetProviderConfigReference(r *xpv1.Reference) {
+	mg.Spec.ProviderConfigReference = r
+}
+
+// SetPublishConnectionDetailsTo of this Ride does nothing, because namespaced
+// resources can't publish connection details to a secret store.
+func (mg *Ride) SetPublishConnectionDetailsTo(r *xpv1.PublishConnectionDetailsTo) {}
+
+// SetWriteConnectionSecretToReference of this Ride. Only the name of the
+// Secret is kept, because it is always in the namespace of the Ride.
+func (mg *Ride) SetWriteConnectionSecretToReference(r *xpv1.SecretReference) {
+	if r == nil {
+		mg.Spec.WriteConnectionSecretToReference = nil
+		return
+	}
+	mg.Spec.WriteConnectionSecretToReference = &xpv1.LocalSecretReference{Name: r.Name}
+}
+
+// GetCondition of this RideOperator.
+func (mg *RideOperator) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// GetDeletionPolicy of this RideOperator.
+func (mg *RideOperator) GetDeletionPolicy() xpv1.DeletionPolicy {
+	return mg.Spec.DeletionPolicy
+}
+
+// GetManagementPolicies of this RideOperator.
+func (mg *RideOperator) GetManagementPolicies() xpv1.ManagementPolicies {
+	return mg.Spec.ManagementPolicies
+}
+
+// GetProviderConfigReference of this RideOperator.
+func (mg *RideOperator) GetProviderConfigReference() *xpv1.Reference {
+	return mg.Spec.ProviderConfigReference
+}
+
+// GetPublishConnectionDetailsTo of this RideOperator. Namespaced resources can't
+// publish connection details to a secret store.
+func (mg *RideOperator) GetPublishConnectionDetailsTo() *xpv1.PublishConnectionDetailsTo {
+	return nil
+}
+
+// GetWriteConnectionSecretToReference of this RideOperator. The Secret is always in
+// the namespace of the RideOperator.
+func (mg *RideOperator) GetWriteConnectionSecretToReference() *xpv1.SecretReference {
+	if mg.Spec.WriteConnectionSecretToReference == nil {
+		return nil
+	}
+	return &xpv1.SecretReference{Name: mg.Spec.WriteConnectionSecretToReference.Name, Namespace: mg.GetNamespace()}
+}
+
+// SetConditions of this RideOperator.
+func (mg *RideOperator) SetConditions(c ...xpv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetDeletionPolicy of this RideOperator.
+func (mg *RideOperator) SetDeletionPolicy(r xpv1.DeletionPolicy) {
+	mg.Spec.DeletionPolicy = r
+}
+
+// SetManagementPolicies of this RideOperator.
+func (mg *RideOperator) SetManagementPolicies(r xpv1.ManagementPolicies) {
+	mg.Spec.ManagementPolicies = r
+}
+
+// SetProviderConfigReference of this RideOperator.
+func (mg *RideOperator) SetProviderConfigReference(r *xpv1.Reference) {
+	mg.Spec.ProviderConfigReference = r
+}
+
+// SetPublishConnectionDetailsTo of this RideOperator does nothing, because namespaced
+// resources can't publish connection details to a secret store.
+func (mg *RideOperator) SetPublishConnectionDetailsTo(r *xpv1.PublishConnectionDetailsTo) {}
+
+// SetWriteConnectionSecretToReference of this RideOperator. Only the name of the
+// Secret is kept, because it is always in the namespace of the RideOperator.
+func (mg *RideOperator) SetWriteConnectionSecretToReference(r *xpv1.SecretReference) {
+	if r == nil {
+		mg.Spec.WriteConnectionSecretToReference = nil
+		return
+	}
+	mg.Spec.WriteConnectionSecretToReference = &xpv1.LocalSecretReference{Name: r.Name}
+}
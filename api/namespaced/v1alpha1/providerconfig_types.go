@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProviderConfigSpec defines the desired state of a namespaced
+// ProviderConfig.
+type ProviderConfigSpec struct {
+	// Endpoint of the park control system, e.g. http://localhost:8090.
+	Endpoint string `json:"endpoint"`
+
+	// Credentials required to authenticate to the park control system.
+	Credentials ProviderCredentials `json:"credentials"`
+}
+
+// ProviderCredentials required to authenticate to the park control system.
+// Unlike those of a cluster-scoped ProviderConfig, they can only be read from
+// a Secret in the namespace of the ProviderConfig, not from the environment or
+// filesystem of the provider.
+// +kubebuilder:validation:XValidation:rule="self.source != 'Secret' || has(self.secretRef)",message="secretRef is required when source is Secret"
+type ProviderCredentials struct {
+	// Source of the provider credentials.
+	// +kubebuilder:validation:Enum=None;Secret
+	Source xpv1.CredentialsSource `json:"source"`
+
+	// SecretRef references a key of a Secret, in the same namespace as this
+	// ProviderConfig, that holds the credentials.
+	// +optional
+	SecretRef *LocalSecretKeySelector `json:"secretRef,omitempty"`
+}
+
+// A LocalSecretKeySelector selects a key of a Secret in the same namespace as
+// the object that references it.
+type LocalSecretKeySelector struct {
+	xpv1.LocalSecretReference `json:",inline"`
+
+	// The key to select.
+	Key string `json:"key"`
+}
+
+// ProviderConfigStatus defines the observed state of a namespaced
+// ProviderConfig.
+type ProviderConfigStatus struct {
+	xpv1.ProviderConfigStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="ENDPOINT",type="string",JSONPath=".spec.endpoint"
+// +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentials.secretRef.name",priority=1
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,provider,themepark}
+
+// ProviderConfig configures which park control system, and which credentials,
+// the namespaced Rides and RideOperators in its namespace are managed with.
+type ProviderConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProviderConfigSpec   `json:"spec"`
+	Status ProviderConfigStatus `json:"status,omitempty"`
+}
+
+var _ resource.ProviderConfig = (*ProviderConfig)(nil)
+
+// +kubebuilder:object:root=true
+
+// ProviderConfigList contains a list of ProviderConfig.
+type ProviderConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProviderConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ProviderConfig{}, &ProviderConfigList{})
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+)
+
+// RideParameters are the configurable fields of a namespaced Ride. They must
+// have the same fields as the cluster-scoped RideParameters, which are used to
+// manage the ride in the park.
+type RideParameters struct {
+	// Type of Ride. Must be a type in the provider's ride catalog, and can't
+	// be changed once the Ride is created.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="type is immutable"
+	Type string `json:"type"`
+	// Capacity is the riders per trip supported on this ride. Defaults to the
+	// capacity of the ride type, or of the ride in the park when adopting one.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self > 0",message="capacity must be positive"
+	// +kubebuilder:validation:XValidation:rule="self <= 500",message="capacity must be at most 500 riders per trip"
+	Capacity *int `json:"capacity,omitempty"`
+
+	// MaxDispatchRate is the most times per hour this ride can be dispatched,
+	// no matter how many operators are assigned. Unlimited when unset.
+	// +optional
+	MaxDispatchRate *int `json:"maxDispatchRate,omitempty"`
+
+	// MinimumCrew is the number of operators required to run this ride.
+	// Defaults to the minimum crew of the ride type, or 1.
+	// +optional
+	MinimumCrew *int `json:"minimumCrew,omitempty"`
+
+	// ParkRef references the Park this ride is in. The ride only operates
+	// while the Park is open. Always open when unset.
+	// +optional
+	ParkRef *xpv1.Reference `json:"parkRef,omitempty"`
+
+	// Maintenance schedules when this ride is under maintenance.
+	// MaintenanceWindows don't apply to namespaced Rides.
+	// +optional
+	Maintenance *v1alpha1.MaintenanceSchedule `json:"maintenance,omitempty"`
+
+	// MaxWaitMinutes is the longest guests should expect to queue for this
+	// ride. The ride reports QueueSaturated when the estimated wait is longer.
+	// Defaults to the provider's --max-wait-minutes.
+	// +optional
+	MaxWaitMinutes *int `json:"maxWaitMinutes,omitempty"`
+}
+
+// RideSpec defines the desired state of Ride.
+type RideSpec struct {
+	ResourceSpec `json:",inline"`
+
+	ForProvider RideParameters `json:"forProvider"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced
+
+// Ride is the Schema for the namespaced rides API.
+type Ride struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RideSpec            `json:"spec,omitempty"`
+	Status v1alpha1.RideStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RideList contains a list of Ride.
+type RideList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Ride `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Ride{}, &RideList{})
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// ResolveReferences of this RideOperator. Like that of the cluster-scoped
+// RideOperator it can't be generated, because Ride is a TypedReference. It also
+// only resolves Rides in the namespace of the RideOperator, which the resolvers
+// angryjet generates don't restrict.
+func (mg *RideOperator) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(inNamespace{Reader: c, namespace: mg.GetNamespace()}, mg)
+
+	current := ""
+	if mg.Spec.ForProvider.Ride != nil {
+		current = mg.Spec.ForProvider.Ride.Name
+	}
+
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: current,
+		Extract:      name(),
+		Reference:    mg.Spec.ForProvider.RideRef,
+		Selector:     mg.Spec.ForProvider.RideSelector,
+		To: reference.To{
+			List:    &RideList{},
+			Managed: &Ride{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "mg.Spec.ForProvider.Ride")
+	}
+	if rsp.ResolvedValue != current {
+		mg.Spec.ForProvider.Ride = &xpv1.TypedReference{
+			APIVersion: GroupVersion.String(),
+			Kind:       RideKind,
+			Name:       rsp.ResolvedValue,
+		}
+	}
+	mg.Spec.ForProvider.RideRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// name extracts the name of a referenced resource.
+func name() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		return mg.GetName()
+	}
+}
+
+// inNamespace is a client.Reader that only reads objects in one namespace.
+type inNamespace struct {
+	client.Reader
+	namespace string
+}
+
+// Get the object with the supplied name in the namespace.
+func (r inNamespace) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	key.Namespace = r.namespace
+	return r.Reader.Get(ctx, key, obj, opts...)
+}
+
+// List the objects in the namespace.
+func (r inNamespace) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return r.Reader.List(ctx, list, append(opts, client.InNamespace(r.namespace))...)
+}
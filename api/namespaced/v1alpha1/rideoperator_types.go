@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+)
+
+// RideOperatorParameters are the configurable fields of a namespaced
+// RideOperator. They must have the same fields as the cluster-scoped
+// RideOperatorParameters, which are used to manage the operator in the park.
+type RideOperatorParameters struct {
+	// Frequency is how often this operator operates ride per hour. Defaults
+	// to 4 when unset.
+	// +optional
+	// +kubebuilder:default=4
+	// +kubebuilder:validation:XValidation:rule="self > 0",message="frequency must be positive"
+	// +kubebuilder:validation:XValidation:rule="self <= 60",message="frequency must be at most 60 per hour"
+	Frequency int `json:"frequency,omitempty"`
+
+	// Certifications held by this operator. An operator only counts towards
+	// the crew of a ride if they hold every certification its type requires.
+	// +optional
+	Certifications []v1alpha1.Certification `json:"certifications,omitempty"`
+
+	// Shifts during which this operator is working. An operator only counts
+	// towards the crew of a ride while on shift. Always on shift when unset.
+	// +optional
+	Shifts []v1alpha1.WeeklyWindow `json:"shifts,omitempty"`
+
+	// TimeZone of the shifts, as an IANA time zone name such as
+	// America/Los_Angeles. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Ride is the ride, in the same namespace, this operator is assigned to.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self.kind == 'Ride'",message="ride must reference a Ride"
+	// +kubebuilder:validation:XValidation:rule="self.apiVersion.startsWith('themepark.m.n3wscott.com/')",message="ride must reference the themepark.m.n3wscott.com API group"
+	Ride *xpv1.TypedReference `json:"ride"`
+
+	// RideRef references the Ride, in the same namespace, this operator is
+	// assigned to, and is used to set Ride.
+	// +optional
+	RideRef *xpv1.Reference `json:"rideRef,omitempty"`
+
+	// RideSelector selects a Ride in the same namespace to reference by label
+	// or controller, and is used to set RideRef.
+	// +optional
+	RideSelector *xpv1.Selector `json:"rideSelector,omitempty"`
+
+	// CredentialRotation configures how this operator's console token is
+	// rotated. It can also be rotated on request by setting the
+	// themepark.n3wscott.com/rotate-credentials annotation to the current
+	// time.
+	// +optional
+	CredentialRotation *v1alpha1.CredentialRotationPolicy `json:"credentialRotation,omitempty"`
+}
+
+// RideOperatorSpec defines the desired state of RideOperator.
+type RideOperatorSpec struct {
+	ResourceSpec `json:",inline"`
+
+	ForProvider RideOperatorParameters `json:"forProvider"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced
+
+// RideOperator is the Schema for the namespaced rideoperators API.
+type RideOperator struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RideOperatorSpec            `json:"spec,omitempty"`
+	Status v1alpha1.RideOperatorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RideOperatorList contains a list of RideOperator.
+type RideOperatorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RideOperator `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RideOperator{}, &RideOperatorList{})
+}
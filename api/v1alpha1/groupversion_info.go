@@ -50,3 +50,35 @@ var (
 	RideOperatorKindAPIVersion   = RideOperatorKind + "." + GroupVersion.String()
 	RideOperatorGroupVersionKind = GroupVersion.WithKind(RideOperatorKind)
 )
+
+// Park type metadata.
+var (
+	ParkKind             = reflect.TypeOf(Park{}).Name()
+	ParkKindAPIVersion   = ParkKind + "." + GroupVersion.String()
+	ParkGroupVersionKind = GroupVersion.WithKind(ParkKind)
+)
+
+// MaintenanceWindow type metadata.
+var (
+	MaintenanceWindowKind             = reflect.TypeOf(MaintenanceWindow{}).Name()
+	MaintenanceWindowKindAPIVersion   = MaintenanceWindowKind + "." + GroupVersion.String()
+	MaintenanceWindowGroupVersionKind = GroupVersion.WithKind(MaintenanceWindowKind)
+)
+
+// ProviderConfig type metadata.
+var (
+	ProviderConfigKind             = reflect.TypeOf(ProviderConfig{}).Name()
+	ProviderConfigGroupKind        = schema.GroupKind{Group: GroupVersion.Group, Kind: ProviderConfigKind}.String()
+	ProviderConfigKindAPIVersion   = ProviderConfigKind + "." + GroupVersion.String()
+	ProviderConfigGroupVersionKind = GroupVersion.WithKind(ProviderConfigKind)
+)
+
+// ProviderConfigUsage type metadata.
+var (
+	ProviderConfigUsageKind                 = reflect.TypeOf(ProviderConfigUsage{}).Name()
+	ProviderConfigUsageGroupKind            = schema.GroupKind{Group: GroupVersion.Group, Kind: ProviderConfigUsageKind}.String()
+	ProviderConfigUsageKindAPIVersion       = ProviderConfigUsageKind + "." + GroupVersion.String()
+	ProviderConfigUsageGroupVersionKind     = GroupVersion.WithKind(ProviderConfigUsageKind)
+	ProviderConfigUsageListKind             = reflect.TypeOf(ProviderConfigUsageList{}).Name()
+	ProviderConfigUsageListGroupVersionKind = GroupVersion.WithKind(ProviderConfigUsageListKind)
+)
@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// A MaintenanceSchedule is when a ride is under maintenance. A ride under
+// maintenance does not operate, and its operators are free to be reassigned.
+type MaintenanceSchedule struct {
+	// OneOff windows of maintenance, e.g. a refurbishment.
+	// +optional
+	OneOff []OneOffWindow `json:"oneOff,omitempty"`
+
+	// Recurring windows of maintenance, e.g. a weekly inspection.
+	// +optional
+	Recurring []WeeklyWindow `json:"recurring,omitempty"`
+
+	// TimeZone of the recurring windows, as an IANA time zone name such as
+	// America/Los_Angeles. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// MaintenanceWindowSpec defines the desired state of MaintenanceWindow.
+type MaintenanceWindowSpec struct {
+	// Rides that are under maintenance during this window.
+	Rides []xpv1.Reference `json:"rides"`
+
+	MaintenanceSchedule `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories={themepark}
+
+// MaintenanceWindow schedules maintenance of Rides, without changing the Rides
+// themselves.
+type MaintenanceWindow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MaintenanceWindowSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// MaintenanceWindowList contains a list of MaintenanceWindow.
+type MaintenanceWindowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MaintenanceWindow `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MaintenanceWindow{}, &MaintenanceWindowList{})
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// A SpecialEvent opens a park outside its opening hours, even on a closure
+// date.
+type SpecialEvent struct {
+	// Name of the event, e.g. Halloween Nights.
+	Name string `json:"name"`
+
+	// Start of the event.
+	Start metav1.Time `json:"start"`
+
+	// End of the event.
+	End metav1.Time `json:"end"`
+}
+
+type ParkParameters struct {
+	// Name of the park as it is known to guests, e.g. Frontier Land.
+	Name string `json:"name"`
+
+	// TimeZone of the park, as an IANA time zone name such as
+	// America/Los_Angeles. Opening hours and closure dates are in this time
+	// zone. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// OpeningHours of the park. The park is always open when unset.
+	// +optional
+	OpeningHours []WeeklyWindow `json:"openingHours,omitempty"`
+
+	// SpecialEvents open the park outside its opening hours.
+	// +optional
+	SpecialEvents []SpecialEvent `json:"specialEvents,omitempty"`
+
+	// ClosureDates are dates, in YYYY-MM-DD format, on which the park is
+	// closed all day unless a special event opens it.
+	// +optional
+	ClosureDates []ClosureDate `json:"closureDates,omitempty"`
+}
+
+// A ClosureDate is a date in YYYY-MM-DD format.
+// +kubebuilder:validation:Pattern=`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`
+type ClosureDate string
+
+// ParkSpec defines the desired state of Park.
+type ParkSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+
+	ForProvider ParkParameters `json:"forProvider"`
+}
+
+// ParkStatus defines the observed state of Park.
+type ParkStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+
+	// Open is true if the park is currently open.
+	// +optional
+	Open bool `json:"open,omitempty"`
+
+	// OpenRides is the number of Rides in this park that are operating.
+	// +optional
+	OpenRides int `json:"openRides,omitempty"`
+
+	// RidersPerHour is the combined throughput of every Ride in this park.
+	// +optional
+	RidersPerHour int `json:"ridersPerHour,omitempty"`
+
+	// StaffingGaps are the Rides in this park that can't operate because too
+	// few certified operators are on shift.
+	// +optional
+	StaffingGaps []xpv1.TypedReference `json:"staffingGaps,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="OPEN",type="boolean",JSONPath=".status.open"
+// +kubebuilder:printcolumn:name="OPEN-RIDES",type="integer",JSONPath=".status.openRides"
+// +kubebuilder:printcolumn:name="RIDERS-PER-HOUR",type="integer",JSONPath=".status.ridersPerHour"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Park is the Schema for the parks API.
+type Park struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ParkSpec   `json:"spec,omitempty"`
+	Status ParkStatus `json:"status,omitempty"`
+}
+
+var _ resource.Managed = (*Park)(nil)
+
+// +kubebuilder:object:root=true
+
+// ParkList contains a list of Park.
+type ParkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Park `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Park{}, &ParkList{})
+}
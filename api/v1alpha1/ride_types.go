@@ -23,10 +23,42 @@ import (
 )
 
 type RideParameters struct {
-	// Type of Ride.
+	// Type of Ride. Must be a type in the provider's ride catalog, and can't
+	// be changed once the Ride is created.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="type is immutable"
 	Type string `json:"type"`
-	// Capacity is the riders per trip supported on this ride.
-	Capacity int `json:"capacity"`
+	// Capacity is the riders per trip supported on this ride. Defaults to the
+	// capacity of the ride type, or of the ride in the park when adopting one.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self > 0",message="capacity must be positive"
+	// +kubebuilder:validation:XValidation:rule="self <= 500",message="capacity must be at most 500 riders per trip"
+	Capacity *int `json:"capacity,omitempty"`
+
+	// MaxDispatchRate is the most times per hour this ride can be dispatched,
+	// no matter how many operators are assigned. Unlimited when unset.
+	// +optional
+	MaxDispatchRate *int `json:"maxDispatchRate,omitempty"`
+
+	// MinimumCrew is the number of operators required to run this ride.
+	// Defaults to the minimum crew of the ride type, or 1.
+	// +optional
+	MinimumCrew *int `json:"minimumCrew,omitempty"`
+
+	// ParkRef references the Park this ride is in. The ride only operates
+	// while the Park is open. Always open when unset.
+	// +optional
+	ParkRef *xpv1.Reference `json:"parkRef,omitempty"`
+
+	// Maintenance schedules when this ride is under maintenance. Maintenance
+	// can also be scheduled using a MaintenanceWindow.
+	// +optional
+	Maintenance *MaintenanceSchedule `json:"maintenance,omitempty"`
+
+	// MaxWaitMinutes is the longest guests should expect to queue for this
+	// ride. The ride reports QueueSaturated when the estimated wait is longer.
+	// Defaults to the provider's --max-wait-minutes.
+	// +optional
+	MaxWaitMinutes *int `json:"maxWaitMinutes,omitempty"`
 }
 
 // RideSpec defines the desired state of Ride.
@@ -36,20 +68,67 @@ type RideSpec struct {
 	ForProvider RideParameters `json:"forProvider"`
 }
 
+// A RideFault is a fault the park reported for a ride.
+type RideFault struct {
+	// Kind of fault, e.g. Breakdown, EmergencyStop or SensorFault.
+	Kind string `json:"kind"`
+
+	// Message describing the fault.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// At is when the fault occurred.
+	At metav1.Time `json:"at"`
+
+	// ClearedAt is when the fault was cleared. The ride is broken down until
+	// it is.
+	// +optional
+	ClearedAt *metav1.Time `json:"clearedAt,omitempty"`
+}
+
 // RideStatus defines the observed state of Ride.
 type RideStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
 
-	// Operator is the operator assigned to this Ride.
+	// Operators are the operators assigned to this Ride that are on shift.
+	// +optional
+	Operators []xpv1.TypedReference `json:"operators,omitempty"`
+
+	// UncertifiedOperators are the operators assigned to this Ride that are
+	// not certified to operate its type, and so do not count towards its crew.
 	// +optional
-	Operator *xpv1.TypedReference `json:"operator"`
+	UncertifiedOperators []xpv1.TypedReference `json:"uncertifiedOperators,omitempty"`
 
 	RidersPerHour int `json:"ridersPerHour"`
+
+	// QueueLength is the number of guests queuing for this Ride.
+	// +optional
+	QueueLength int `json:"queueLength,omitempty"`
+
+	// EstimatedWaitMinutes is how long a guest joining the queue can expect
+	// to wait at the current throughput. Unset while guests are queuing for a
+	// Ride that is not operating.
+	// +optional
+	EstimatedWaitMinutes *int `json:"estimatedWaitMinutes,omitempty"`
+
+	// LastFault is the most recent fault of this Ride.
+	// +optional
+	LastFault *RideFault `json:"lastFault,omitempty"`
+
+	// FaultCount is the number of faults this Ride has had.
+	// +optional
+	FaultCount int `json:"faultCount,omitempty"`
+
+	// DowntimeMinutes is how long this Ride has been broken down by faults,
+	// including any fault that has not been cleared yet.
+	// +optional
+	DowntimeMinutes int `json:"downtimeMinutes,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
 
 // Ride is the Schema for the rides API.
 type Ride struct {
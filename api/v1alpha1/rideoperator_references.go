@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// ResolveReferences of this RideOperator. This follows the resolvers angryjet
+// generates, but can't be generated. angryjet only resolves references into
+// string fields, and Ride is a TypedReference so that it records the API
+// version and kind of the Ride, which the webhooks default and validate. Don't
+// mark Ride with +crossplane:generate:reference; angryjet would generate a
+// second ResolveReferences that does not compile.
+func (mg *RideOperator) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	current := ""
+	if mg.Spec.ForProvider.Ride != nil {
+		current = mg.Spec.ForProvider.Ride.Name
+	}
+
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: current,
+		Extract:      name(),
+		Reference:    mg.Spec.ForProvider.RideRef,
+		Selector:     mg.Spec.ForProvider.RideSelector,
+		To: reference.To{
+			List:    &RideList{},
+			Managed: &Ride{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "mg.Spec.ForProvider.Ride")
+	}
+	if rsp.ResolvedValue != current {
+		mg.Spec.ForProvider.Ride = &xpv1.TypedReference{
+			APIVersion: GroupVersion.String(),
+			Kind:       RideKind,
+			Name:       rsp.ResolvedValue,
+		}
+	}
+	mg.Spec.ForProvider.RideRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// name extracts the name of a referenced resource. Rides are referenced by
+// name rather than external name because operators are matched to Rides in
+// the cluster, not in the park.
+func name() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		return mg.GetName()
+	}
+}
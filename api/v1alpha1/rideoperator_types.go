@@ -19,15 +19,81 @@ package v1alpha1
 import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
+// A Certification qualifies an operator to operate types of ride that
+// require it.
+type Certification struct {
+	// Name of the certification, e.g. coaster-operations.
+	Name string `json:"name"`
+
+	// ExpiresAt is when the certification lapses. It never lapses when unset.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// A CredentialRotationPolicy configures how an operator's console token is
+// rotated.
+type CredentialRotationPolicy struct {
+	// MaxAge is how long a console token is used before it is rotated. Only
+	// rotated on request when unset.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+
+	// Overlap is how long the previous console token remains valid after it
+	// is rotated, so that whatever uses it has time to switch to the new one.
+	// Defaults to 5m.
+	// +optional
+	Overlap *metav1.Duration `json:"overlap,omitempty"`
+}
+
 type RideOperatorParameters struct {
-	// Frequency is how often this operator operates ride per hour.
-	Frequency int `json:"frequency"`
+	// Frequency is how often this operator operates ride per hour. Defaults
+	// to 4 when unset.
+	// +optional
+	// +kubebuilder:default=4
+	// +kubebuilder:validation:XValidation:rule="self > 0",message="frequency must be positive"
+	// +kubebuilder:validation:XValidation:rule="self <= 60",message="frequency must be at most 60 per hour"
+	Frequency int `json:"frequency,omitempty"`
+
+	// Certifications held by this operator. An operator only counts towards
+	// the crew of a ride if they hold every certification its type requires.
+	// +optional
+	Certifications []Certification `json:"certifications,omitempty"`
+
+	// Shifts during which this operator is working. An operator only counts
+	// towards the crew of a ride while on shift. Always on shift when unset.
+	// +optional
+	Shifts []WeeklyWindow `json:"shifts,omitempty"`
+
+	// TimeZone of the shifts, as an IANA time zone name such as
+	// America/Los_Angeles. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
 
 	// Ride is the ride this operator is assigned to.
 	// +optional
+	// +kubebuilder:validation:XValidation:rule="self.kind == 'Ride'",message="ride must reference a Ride"
+	// +kubebuilder:validation:XValidation:rule="self.apiVersion.startsWith('themepark.n3wscott.com/')",message="ride must reference the themepark.n3wscott.com API group"
 	Ride *xpv1.TypedReference `json:"ride"`
+
+	// RideRef references the Ride this operator is assigned to, and is used
+	// to set Ride.
+	// +optional
+	RideRef *xpv1.Reference `json:"rideRef,omitempty"`
+
+	// RideSelector selects a Ride to reference by label or controller, and is
+	// used to set RideRef.
+	// +optional
+	RideSelector *xpv1.Selector `json:"rideSelector,omitempty"`
+
+	// CredentialRotation configures how this operator's console token is
+	// rotated. It can also be rotated on request by setting the
+	// themepark.n3wscott.com/rotate-credentials annotation to the current
+	// time.
+	// +optional
+	CredentialRotation *CredentialRotationPolicy `json:"credentialRotation,omitempty"`
 }
 
 // RideOperatorSpec defines the desired state of RideOperator.
@@ -40,11 +106,31 @@ type RideOperatorSpec struct {
 // RideOperatorStatus defines the observed state of RideOperator.
 type RideOperatorStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
+
+	// RideUID is the UID of the Ride this operator is assigned to, if it
+	// exists.
+	// +optional
+	RideUID types.UID `json:"rideUID,omitempty"`
+
+	// OnShift is true if this operator is currently on shift.
+	// +optional
+	OnShift bool `json:"onShift,omitempty"`
+
+	// Available is true if this operator is on shift but not operating a
+	// ride, because they are not assigned to one or it is under maintenance,
+	// and so is free to be reassigned.
+	// +optional
+	Available bool `json:"available,omitempty"`
+
+	// CredentialsIssuedAt is when this operator's console token was issued.
+	// +optional
+	CredentialsIssuedAt *metav1.Time `json:"credentialsIssuedAt,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
 
 // RideOperator is the Schema for the rideoperators API.
 type RideOperator struct {
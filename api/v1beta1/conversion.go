@@ -0,0 +1,280 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+)
+
+// ConvertTo converts this Ride to the hub version.
+func (src *Ride) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1alpha1.Ride)
+	if !ok {
+		return errors.Errorf("cannot convert Ride to %T", hub)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	p := src.Spec.ForProvider
+	dst.Spec = v1alpha1.RideSpec{
+		ResourceSpec: src.Spec.ResourceSpec,
+		ForProvider: v1alpha1.RideParameters{
+			Type:            p.Type,
+			Capacity:        p.Capacity,
+			MaxDispatchRate: p.MaxDispatchRate,
+			MinimumCrew:     p.MinimumCrew,
+			ParkRef:         p.ParkRef,
+			Maintenance:     maintenanceToHub(p.Maintenance),
+			MaxWaitMinutes:  p.MaxWaitMinutes,
+		},
+	}
+
+	o := src.Status.AtProvider
+	dst.Status = v1alpha1.RideStatus{
+		ResourceStatus:       src.Status.ResourceStatus,
+		Operators:            o.Operators,
+		UncertifiedOperators: o.UncertifiedOperators,
+		RidersPerHour:        o.RidersPerHour,
+		QueueLength:          o.QueueLength,
+		EstimatedWaitMinutes: o.EstimatedWaitMinutes,
+		LastFault:            faultToHub(o.LastFault),
+		FaultCount:           o.FaultCount,
+		DowntimeMinutes:      o.DowntimeMinutes,
+	}
+
+	return nil
+}
+
+// ConvertFrom converts the hub version to this Ride.
+func (dst *Ride) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1alpha1.Ride)
+	if !ok {
+		return errors.Errorf("cannot convert %T to Ride", hub)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	p := src.Spec.ForProvider
+	dst.Spec = RideSpec{
+		ResourceSpec: src.Spec.ResourceSpec,
+		ForProvider: RideParameters{
+			Type:            p.Type,
+			Capacity:        p.Capacity,
+			MaxDispatchRate: p.MaxDispatchRate,
+			MinimumCrew:     p.MinimumCrew,
+			ParkRef:         p.ParkRef,
+			Maintenance:     maintenanceFromHub(p.Maintenance),
+			MaxWaitMinutes:  p.MaxWaitMinutes,
+		},
+	}
+
+	s := src.Status
+	dst.Status = RideStatus{
+		ResourceStatus: s.ResourceStatus,
+		AtProvider: RideObservation{
+			Operators:            s.Operators,
+			UncertifiedOperators: s.UncertifiedOperators,
+			RidersPerHour:        s.RidersPerHour,
+			QueueLength:          s.QueueLength,
+			EstimatedWaitMinutes: s.EstimatedWaitMinutes,
+			LastFault:            faultFromHub(s.LastFault),
+			FaultCount:           s.FaultCount,
+			DowntimeMinutes:      s.DowntimeMinutes,
+		},
+	}
+
+	return nil
+}
+
+// ConvertTo converts this RideOperator to the hub version.
+func (src *RideOperator) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1alpha1.RideOperator)
+	if !ok {
+		return errors.Errorf("cannot convert RideOperator to %T", hub)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	p := src.Spec.ForProvider
+	dst.Spec = v1alpha1.RideOperatorSpec{
+		ResourceSpec: src.Spec.ResourceSpec,
+		ForProvider: v1alpha1.RideOperatorParameters{
+			Frequency:      p.Frequency,
+			Certifications: certificationsToHub(p.Certifications),
+			Shifts:         windowsToHub(p.Shifts),
+			TimeZone:       p.TimeZone,
+			Ride:           p.Ride,
+			RideRef:        p.RideRef,
+			RideSelector:   p.RideSelector,
+		},
+	}
+	if r := p.CredentialRotation; r != nil {
+		dst.Spec.ForProvider.CredentialRotation = &v1alpha1.CredentialRotationPolicy{MaxAge: r.MaxAge, Overlap: r.Overlap}
+	}
+
+	o := src.Status.AtProvider
+	dst.Status = v1alpha1.RideOperatorStatus{
+		ResourceStatus:      src.Status.ResourceStatus,
+		RideUID:             o.RideUID,
+		OnShift:             o.OnShift,
+		Available:           o.Available,
+		CredentialsIssuedAt: o.CredentialsIssuedAt,
+	}
+
+	return nil
+}
+
+// ConvertFrom converts the hub version to this RideOperator.
+func (dst *RideOperator) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1alpha1.RideOperator)
+	if !ok {
+		return errors.Errorf("cannot convert %T to RideOperator", hub)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	p := src.Spec.ForProvider
+	dst.Spec = RideOperatorSpec{
+		ResourceSpec: src.Spec.ResourceSpec,
+		ForProvider: RideOperatorParameters{
+			Frequency:      p.Frequency,
+			Certifications: certificationsFromHub(p.Certifications),
+			Shifts:         windowsFromHub(p.Shifts),
+			TimeZone:       p.TimeZone,
+			Ride:           p.Ride,
+			RideRef:        p.RideRef,
+			RideSelector:   p.RideSelector,
+		},
+	}
+	if r := p.CredentialRotation; r != nil {
+		dst.Spec.ForProvider.CredentialRotation = &CredentialRotationPolicy{MaxAge: r.MaxAge, Overlap: r.Overlap}
+	}
+
+	s := src.Status
+	dst.Status = RideOperatorStatus{
+		ResourceStatus: s.ResourceStatus,
+		AtProvider: RideOperatorObservation{
+			RideUID:             s.RideUID,
+			OnShift:             s.OnShift,
+			Available:           s.Available,
+			CredentialsIssuedAt: s.CredentialsIssuedAt,
+		},
+	}
+
+	return nil
+}
+
+func faultToHub(f *RideFault) *v1alpha1.RideFault {
+	if f == nil {
+		return nil
+	}
+	return &v1alpha1.RideFault{Kind: string(f.Kind), Message: f.Message, At: f.At, ClearedAt: f.ClearedAt}
+}
+
+func faultFromHub(f *v1alpha1.RideFault) *RideFault {
+	if f == nil {
+		return nil
+	}
+	return &RideFault{Kind: FaultKind(f.Kind), Message: f.Message, At: f.At, ClearedAt: f.ClearedAt}
+}
+
+func certificationsToHub(cs []Certification) []v1alpha1.Certification {
+	if cs == nil {
+		return nil
+	}
+	out := make([]v1alpha1.Certification, len(cs))
+	for i, c := range cs {
+		out[i] = v1alpha1.Certification{Name: c.Name, ExpiresAt: c.ExpiresAt}
+	}
+	return out
+}
+
+func certificationsFromHub(cs []v1alpha1.Certification) []Certification {
+	if cs == nil {
+		return nil
+	}
+	out := make([]Certification, len(cs))
+	for i, c := range cs {
+		out[i] = Certification{Name: c.Name, ExpiresAt: c.ExpiresAt}
+	}
+	return out
+}
+
+func maintenanceToHub(m *MaintenanceSchedule) *v1alpha1.MaintenanceSchedule {
+	if m == nil {
+		return nil
+	}
+	out := &v1alpha1.MaintenanceSchedule{Recurring: windowsToHub(m.Recurring), TimeZone: m.TimeZone}
+	if m.OneOff != nil {
+		out.OneOff = make([]v1alpha1.OneOffWindow, len(m.OneOff))
+		for i, w := range m.OneOff {
+			out.OneOff[i] = v1alpha1.OneOffWindow{Start: w.Start, End: w.End}
+		}
+	}
+	return out
+}
+
+func maintenanceFromHub(m *v1alpha1.MaintenanceSchedule) *MaintenanceSchedule {
+	if m == nil {
+		return nil
+	}
+	out := &MaintenanceSchedule{Recurring: windowsFromHub(m.Recurring), TimeZone: m.TimeZone}
+	if m.OneOff != nil {
+		out.OneOff = make([]OneOffWindow, len(m.OneOff))
+		for i, w := range m.OneOff {
+			out.OneOff[i] = OneOffWindow{Start: w.Start, End: w.End}
+		}
+	}
+	return out
+}
+
+func windowsToHub(ws []WeeklyWindow) []v1alpha1.WeeklyWindow {
+	if ws == nil {
+		return nil
+	}
+	out := make([]v1alpha1.WeeklyWindow, len(ws))
+	for i, w := range ws {
+		out[i] = v1alpha1.WeeklyWindow{Start: w.Start, End: w.End}
+		if w.Days != nil {
+			out[i].Days = make([]v1alpha1.Weekday, len(w.Days))
+			for j, d := range w.Days {
+				out[i].Days[j] = v1alpha1.Weekday(d)
+			}
+		}
+	}
+	return out
+}
+
+func windowsFromHub(ws []v1alpha1.WeeklyWindow) []WeeklyWindow {
+	if ws == nil {
+		return nil
+	}
+	out := make([]WeeklyWindow, len(ws))
+	for i, w := range ws {
+		out[i] = WeeklyWindow{Start: w.Start, End: w.End}
+		if w.Days != nil {
+			out[i].Days = make([]Weekday, len(w.Days))
+			for j, d := range w.Days {
+				out[i].Days[j] = Weekday(d)
+			}
+		}
+	}
+	return out
+}
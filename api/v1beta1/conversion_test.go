@@ -0,0 +1,104 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	fuzz "github.com/google/gofuzz"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+)
+
+// roundTrips is how many randomly filled objects each test converts.
+const roundTrips = 1000
+
+func TestRoundTrip(t *testing.T) {
+	cases := map[string]struct {
+		spoke func() conversion.Convertible
+		hub   func() conversion.Hub
+	}{
+		"Ride": {
+			spoke: func() conversion.Convertible { return &Ride{} },
+			hub:   func() conversion.Hub { return &v1alpha1.Ride{} },
+		},
+		"RideOperator": {
+			spoke: func() conversion.Convertible { return &RideOperator{} },
+			hub:   func() conversion.Hub { return &v1alpha1.RideOperator{} },
+		},
+	}
+
+	f := fuzz.New().NilChance(0.2).NumElements(0, 3).Funcs(
+		// The conversion webhook sets the apiVersion and kind of the objects it
+		// converts, so the conversion functions don't.
+		func(tm *metav1.TypeMeta, _ fuzz.Continue) { *tm = metav1.TypeMeta{} },
+	)
+
+	for name, tc := range cases {
+		t.Run(name+"/FromV1beta1", func(t *testing.T) {
+			for range roundTrips {
+				want := tc.spoke()
+				f.Fuzz(want)
+
+				hub := tc.hub()
+				if err := want.ConvertTo(hub); err != nil {
+					t.Fatalf("ConvertTo(...): %v", err)
+				}
+				got := tc.spoke()
+				if err := got.ConvertFrom(hub); err != nil {
+					t.Fatalf("ConvertFrom(...): %v", err)
+				}
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Fatalf("v1beta1 -> v1alpha1 -> v1beta1: -want, +got:\n%s", diff)
+				}
+			}
+		})
+
+		t.Run(name+"/FromV1alpha1", func(t *testing.T) {
+			for range roundTrips {
+				want := tc.hub()
+				f.Fuzz(want)
+
+				spoke := tc.spoke()
+				if err := spoke.ConvertFrom(want); err != nil {
+					t.Fatalf("ConvertFrom(...): %v", err)
+				}
+				got := tc.hub()
+				if err := spoke.ConvertTo(got); err != nil {
+					t.Fatalf("ConvertTo(...): %v", err)
+				}
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Fatalf("v1alpha1 -> v1beta1 -> v1alpha1: -want, +got:\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertWrongHub(t *testing.T) {
+	if err := (&Ride{}).ConvertTo(&v1alpha1.RideOperator{}); err == nil {
+		t.Error("Ride.ConvertTo(RideOperator): want error, got nil")
+	}
+	if err := (&RideOperator{}).ConvertFrom(&v1alpha1.Ride{}); err == nil {
+		t.Error("RideOperator.ConvertFrom(Ride): want error, got nil")
+	}
+}
+
+func TestConvertUnsetFrequency(t *testing.T) {
+	// The API server only defaults a frequency that is absent, so converting
+	// a RideOperator that leaves it unset must not write a frequency of 0.
+	hub := &v1alpha1.RideOperator{}
+	if err := (&RideOperator{}).ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo(...): %v", err)
+	}
+	got, err := json.Marshal(hub)
+	if err != nil {
+		t.Fatalf("json.Marshal(...): %v", err)
+	}
+	if strings.Contains(string(got), `"frequency"`) {
+		t.Errorf("json.Marshal(...): want no frequency, got %s", got)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1beta1 contains API Schema definitions for the themepark.n3wscott.com v1beta1 API group.
+// +kubebuilder:object:generate=true
+// +groupName=themepark.n3wscott.com
+package v1beta1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+var (
+	// GroupVersion is group version used to register these objects.
+	GroupVersion = schema.GroupVersion{Group: "themepark.n3wscott.com", Version: "v1beta1"}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme.
+	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
+
+	// AddToScheme adds the types in this group-version to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+// Ride type metadata.
+var (
+	RideKind             = reflect.TypeOf(Ride{}).Name()
+	RideKindAPIVersion   = RideKind + "." + GroupVersion.String()
+	RideGroupVersionKind = GroupVersion.WithKind(RideKind)
+)
+
+// RideOperator type metadata.
+var (
+	RideOperatorKind             = reflect.TypeOf(RideOperator{}).Name()
+	RideOperatorKindAPIVersion   = RideOperatorKind + "." + GroupVersion.String()
+	RideOperatorGroupVersionKind = GroupVersion.WithKind(RideOperatorKind)
+)
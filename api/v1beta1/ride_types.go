@@ -0,0 +1,181 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RideParameters are the configurable fields of a Ride.
+type RideParameters struct {
+	// Type of Ride. Must be a type in the provider's ride catalog, and can't
+	// be changed once the Ride is created.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="type is immutable"
+	Type string `json:"type"`
+
+	// Capacity is the riders per trip supported on this ride. Defaults to the
+	// capacity of the ride type, or of the ride in the park when adopting one.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self > 0",message="capacity must be positive"
+	// +kubebuilder:validation:XValidation:rule="self <= 500",message="capacity must be at most 500 riders per trip"
+	Capacity *int `json:"capacity,omitempty"`
+
+	// MaxDispatchRate is the most times per hour this ride can be dispatched,
+	// no matter how many operators are assigned. Unlimited when unset.
+	// +optional
+	MaxDispatchRate *int `json:"maxDispatchRate,omitempty"`
+
+	// MinimumCrew is the number of operators required to run this ride.
+	// Defaults to the minimum crew of the ride type, or 1.
+	// +optional
+	MinimumCrew *int `json:"minimumCrew,omitempty"`
+
+	// ParkRef references the Park this ride is in. The ride only operates
+	// while the Park is open. Always open when unset.
+	// +optional
+	ParkRef *xpv1.Reference `json:"parkRef,omitempty"`
+
+	// Maintenance schedules when this ride is under maintenance. Maintenance
+	// can also be scheduled using a MaintenanceWindow.
+	// +optional
+	Maintenance *MaintenanceSchedule `json:"maintenance,omitempty"`
+
+	// MaxWaitMinutes is the longest guests should expect to queue for this
+	// ride. The ride reports QueueSaturated when the estimated wait is longer.
+	// Defaults to the provider's --max-wait-minutes.
+	// +optional
+	MaxWaitMinutes *int `json:"maxWaitMinutes,omitempty"`
+}
+
+// A FaultKind is a kind of fault the park reports for a ride.
+// +kubebuilder:validation:Enum=Breakdown;EmergencyStop;SensorFault
+type FaultKind string
+
+// Kinds of fault.
+const (
+	FaultBreakdown     FaultKind = "Breakdown"
+	FaultEmergencyStop FaultKind = "EmergencyStop"
+	FaultSensor        FaultKind = "SensorFault"
+)
+
+// A RideFault is a fault the park reported for a ride.
+type RideFault struct {
+	// Kind of fault.
+	Kind FaultKind `json:"kind"`
+
+	// Message describing the fault.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// At is when the fault occurred.
+	At metav1.Time `json:"at"`
+
+	// ClearedAt is when the fault was cleared. The ride is broken down until
+	// it is.
+	// +optional
+	ClearedAt *metav1.Time `json:"clearedAt,omitempty"`
+}
+
+// RideObservation are the observable fields of a Ride.
+type RideObservation struct {
+	// Operators are the operators assigned to this Ride that are on shift.
+	// +optional
+	Operators []xpv1.TypedReference `json:"operators,omitempty"`
+
+	// UncertifiedOperators are the operators assigned to this Ride that are
+	// not certified to operate its type, and so do not count towards its crew.
+	// +optional
+	UncertifiedOperators []xpv1.TypedReference `json:"uncertifiedOperators,omitempty"`
+
+	// RidersPerHour is how many riders this Ride can carry per hour with the
+	// operators currently assigned to it.
+	// +optional
+	RidersPerHour int `json:"ridersPerHour,omitempty"`
+
+	// QueueLength is the number of guests queuing for this Ride.
+	// +optional
+	QueueLength int `json:"queueLength,omitempty"`
+
+	// EstimatedWaitMinutes is how long a guest joining the queue can expect
+	// to wait at the current throughput. Unset while guests are queuing for a
+	// Ride that is not operating.
+	// +optional
+	EstimatedWaitMinutes *int `json:"estimatedWaitMinutes,omitempty"`
+
+	// LastFault is the most recent fault of this Ride.
+	// +optional
+	LastFault *RideFault `json:"lastFault,omitempty"`
+
+	// FaultCount is the number of faults this Ride has had.
+	// +optional
+	FaultCount int `json:"faultCount,omitempty"`
+
+	// DowntimeMinutes is how long this Ride has been broken down by faults,
+	// including any fault that has not been cleared yet.
+	// +optional
+	DowntimeMinutes int `json:"downtimeMinutes,omitempty"`
+}
+
+// RideSpec defines the desired state of Ride.
+type RideSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+
+	ForProvider RideParameters `json:"forProvider"`
+}
+
+// RideStatus defines the observed state of Ride.
+type RideStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+
+	// +optional
+	AtProvider RideObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:unservedversion
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Ride is the Schema for the rides API.
+type Ride struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RideSpec   `json:"spec,omitempty"`
+	Status RideStatus `json:"status,omitempty"`
+}
+
+var _ resource.Managed = (*Ride)(nil)
+
+// +kubebuilder:object:root=true
+
+// RideList contains a list of Ride.
+type RideList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Ride `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Ride{}, &RideList{})
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// A Weekday is a day of the week.
+// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+type Weekday string
+
+// A WeeklyWindow is a window of time that recurs every week.
+type WeeklyWindow struct {
+	// Days of the week the window starts on. Every day when unset.
+	// +optional
+	Days []Weekday `json:"days,omitempty"`
+
+	// Start time of day of the window, in HH:MM format.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// End time of day of the window, in HH:MM format. A window that ends
+	// before it starts runs past midnight into the next day, and one that ends
+	// when it starts lasts all day.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
+}
+
+// A OneOffWindow is a window of time that happens once.
+type OneOffWindow struct {
+	// Start of the window.
+	Start metav1.Time `json:"start"`
+
+	// End of the window.
+	End metav1.Time `json:"end"`
+}
+
+// A MaintenanceSchedule is when a ride is under maintenance. A ride under
+// maintenance does not operate, and its operators are free to be reassigned.
+type MaintenanceSchedule struct {
+	// OneOff windows of maintenance, e.g. a refurbishment.
+	// +optional
+	OneOff []OneOffWindow `json:"oneOff,omitempty"`
+
+	// Recurring windows of maintenance, e.g. a weekly inspection.
+	// +optional
+	Recurring []WeeklyWindow `json:"recurring,omitempty"`
+
+	// TimeZone of the recurring windows, as an IANA time zone name such as
+	// America/Los_Angeles. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
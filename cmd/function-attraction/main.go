@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command function-attraction runs a composition function that composes an
+// Attraction from a Ride and the RideOperators needed to run it.
+package main
+
+import (
+	"flag"
+	"os"
+
+	"k8s.io/klog/v2"
+	"k8s.io/klog/v2/textlogger"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/function-sdk-go"
+
+	"github.com/n3wscott/theme-park-provider/pkg/attraction"
+	"github.com/n3wscott/theme-park-provider/pkg/catalog"
+)
+
+func main() {
+	var (
+		debug       bool
+		network     string
+		addr        string
+		tlsCertsDir string
+		insecure    bool
+		rideCatalog string
+	)
+	flag.BoolVar(&debug, "debug", false, "Enable debug logging")
+	flag.StringVar(&network, "network", "tcp", "The network the function listens for gRPC connections on")
+	flag.StringVar(&addr, "address", ":9443", "The address the function listens for gRPC connections on")
+	flag.StringVar(&tlsCertsDir, "tls-server-certs-dir", os.Getenv("TLS_SERVER_CERTS_DIR"), "Directory containing the server certs (tls.key, tls.crt) and the CA used to verify client certificates (ca.crt)")
+	flag.BoolVar(&insecure, "insecure", false, "Serve without mTLS credentials, ignoring --tls-server-certs-dir")
+	flag.StringVar(&rideCatalog, "ride-catalog", "", "Path to a YAML file of ride types to add to the built-in catalog")
+	flag.Parse()
+
+	// Initialize klog flags
+	klog.InitFlags(nil)
+	if debug {
+		_ = flag.Set("v", "5")
+	}
+
+	log := logging.NewLogrLogger(textlogger.NewLogger(textlogger.NewConfig()).WithName("function-attraction"))
+
+	// Load the ride types an Attraction can be composed of
+	rideTypes := catalog.Default
+	if rideCatalog != "" {
+		var err error
+		rideTypes, err = catalog.Load(rideCatalog)
+		if err != nil {
+			log.Info("Failed to load ride catalog", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	log.Info("Attraction function started", "network", network, "address", addr)
+	err := function.Serve(&attraction.Function{Log: log, RideTypes: rideTypes},
+		function.Listen(network, addr),
+		function.MTLSCertificates(tlsCertsDir),
+		function.Insecure(insecure))
+	if err != nil {
+		log.Info("Failed to serve function", "error", err)
+		os.Exit(1)
+	}
+}
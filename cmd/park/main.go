@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command park runs an in-memory ride control system for local development.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/klog/v2/textlogger"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/n3wscott/theme-park-provider/pkg/park"
+)
+
+func main() {
+	var (
+		debug bool
+		addr  string
+		token string
+		mtbf  time.Duration
+		seed  uint64
+	)
+	flag.BoolVar(&debug, "debug", false, "Enable debug logging")
+	flag.StringVar(&addr, "address", ":8090", "The address the park control system API listens on")
+	flag.StringVar(&token, "token", os.Getenv("PARK_TOKEN"), "Bearer token clients must present, if any")
+	flag.DurationVar(&mtbf, "mtbf", 0, "Mean time between randomly injected faults of each ride, or 0 to never inject faults")
+	flag.Uint64Var(&seed, "fault-seed", 0, "Seed for randomly injected faults. Any value, including 0, is reproducible. Seeded from the clock when unset")
+	flag.Parse()
+
+	// Initialize klog flags
+	klog.InitFlags(nil)
+	if debug {
+		_ = flag.Set("v", "5")
+	}
+
+	log := logging.NewLogrLogger(textlogger.NewLogger(textlogger.NewConfig()).WithName("park"))
+
+	// Every seed is valid, so only seed from the clock if none was given
+	seeded := false
+	flag.Visit(func(f *flag.Flag) { seeded = seeded || f.Name == "fault-seed" })
+	if !seeded {
+		seed = uint64(time.Now().UnixNano())
+	}
+	opts := []park.ServerOption{park.WithToken(token)}
+	if mtbf > 0 {
+		log.Info("Injecting random ride faults", "mtbf", mtbf, "seed", seed)
+		opts = append(opts, park.WithFaultInjection(mtbf, seed))
+	}
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           park.NewServer(opts...),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Setup signal handling
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		log.Info("Shutting down")
+		shutdown, done := context.WithTimeout(context.Background(), 5*time.Second)
+		defer done()
+		_ = srv.Shutdown(shutdown)
+	}()
+
+	log.Info("Park control system started", "address", addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Info("Failed to serve park control system", "error", err)
+		os.Exit(1)
+	}
+}
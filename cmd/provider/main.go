@@ -28,12 +28,19 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog/v2"
 	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
 
 	"github.com/crossplane/crossplane-runtime/pkg/external/server"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	themeparkmn3wscottcomv1alpha1 "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
 	themeparkn3wscottcomv1alpha1 "github.com/n3wscott/theme-park-provider/api/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/pkg/catalog"
+	"github.com/n3wscott/theme-park-provider/pkg/reconciler/park"
 	"github.com/n3wscott/theme-park-provider/pkg/reconciler/ride"
 	"github.com/n3wscott/theme-park-provider/pkg/reconciler/rideoperator"
+	"github.com/n3wscott/theme-park-provider/pkg/requeue"
 )
 
 var (
@@ -46,11 +53,16 @@ func init() {
 	_ = scheme.AddToScheme(s)
 	// Add custom API types
 	_ = themeparkn3wscottcomv1alpha1.AddToScheme(s)
+	_ = themeparkmn3wscottcomv1alpha1.AddToScheme(s)
 }
 
 func main() {
 	var debug bool
+	var rideCatalog string
+	var maxWaitMinutes int
 	flag.BoolVar(&debug, "debug", false, "Enable debug logging")
+	flag.StringVar(&rideCatalog, "ride-catalog", "", "Path to a YAML file of ride types to add to the built-in catalog")
+	flag.IntVar(&maxWaitMinutes, "max-wait-minutes", ride.DefaultMaxWaitMinutes, "Longest guests should queue for a Ride before it reports QueueSaturated")
 	flag.Parse()
 
 	// Initialize klog flags
@@ -64,6 +76,34 @@ func main() {
 
 	log.Info("Starting theme park provider")
 
+	// Create a Kubernetes client for handlers to resolve ProviderConfigs and
+	// look up related resources. The kubeconfig is taken from --kubeconfig, $KUBECONFIG or the
+	// in-cluster config.
+	cfg, err := config.GetConfig()
+	if err != nil {
+		log.Info("Failed to load Kubernetes config", "error", err)
+		os.Exit(1)
+	}
+	kube, err := client.New(cfg, client.Options{Scheme: s})
+	if err != nil {
+		log.Info("Failed to create Kubernetes client", "error", err)
+		os.Exit(1)
+	}
+
+	// Load the ride types the park can build
+	rideTypes := catalog.Default
+	if rideCatalog != "" {
+		rideTypes, err = catalog.Load(rideCatalog)
+		if err != nil {
+			log.Info("Failed to load ride catalog", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Reconcile resources when shifts start and end and parks open and close,
+	// not just when polled
+	requeuer := requeue.NewScheduler(kube, log.WithValues("component", "requeue"))
+
 	// Get gRPC configuration from environment
 	grpcEndpoint := os.Getenv("GRPC_ENDPOINT")
 	if grpcEndpoint == "" {
@@ -86,6 +126,33 @@ func main() {
 		cancel()
 	}()
 
+	// Cache the RideOperators and MaintenanceWindows every Ride looks up when
+	// it is observed, indexed by the Rides they apply to, and the Rides every
+	// Park looks up, indexed by the Park they are in
+	rideCache, err := cache.New(cfg, cache.Options{Scheme: s})
+	if err != nil {
+		log.Info("Failed to create Kubernetes cache", "error", err)
+		os.Exit(1)
+	}
+	if err := ride.SetupIndexes(ctx, rideCache); err != nil {
+		log.Info("Failed to index Kubernetes cache", "error", err)
+		os.Exit(1)
+	}
+	if err := park.SetupIndexes(ctx, rideCache); err != nil {
+		log.Info("Failed to index Kubernetes cache", "error", err)
+		os.Exit(1)
+	}
+	go func() {
+		if err := rideCache.Start(ctx); err != nil {
+			log.Info("Kubernetes cache stopped", "error", err)
+			cancel()
+		}
+	}()
+	if !rideCache.WaitForCacheSync(ctx) {
+		log.Info("Failed to sync Kubernetes cache")
+		os.Exit(1)
+	}
+
 	// Set up the gRPC provider server
 	log.Info("Setting up gRPC provider server", "endpoint", grpcEndpoint)
 
@@ -110,11 +177,30 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Register Park handler directly with the logger
+	if err := builder.RegisterHandler(
+		themeparkn3wscottcomv1alpha1.ParkGroupVersionKind,
+		&park.ConnectorWrapper{
+			Log:     log.WithValues("handler", "Park"),
+			Client:  kube,
+			Cache:   rideCache,
+			Requeue: requeuer,
+		},
+	); err != nil {
+		log.Info("Failed to register Park handler", "error", err)
+		os.Exit(1)
+	}
+
 	// Register Ride handler directly with the logger
 	if err := builder.RegisterHandler(
 		themeparkn3wscottcomv1alpha1.RideGroupVersionKind,
 		&ride.ConnectorWrapper{
-			Log: log.WithValues("handler", "Ride"),
+			Log:            log.WithValues("handler", "Ride"),
+			Client:         kube,
+			Cache:          rideCache,
+			Catalog:        rideTypes,
+			Requeue:        requeuer,
+			MaxWaitMinutes: maxWaitMinutes,
 		},
 	); err != nil {
 		log.Info("Failed to register Ride handler", "error", err)
@@ -125,13 +211,44 @@ func main() {
 	if err := builder.RegisterHandler(
 		themeparkn3wscottcomv1alpha1.RideOperatorGroupVersionKind,
 		&rideoperator.ConnectorWrapper{
-			Log: log.WithValues("handler", "RideOperator"),
+			Log:     log.WithValues("handler", "RideOperator"),
+			Client:  kube,
+			Requeue: requeuer,
 		},
 	); err != nil {
 		log.Info("Failed to register RideOperator handler", "error", err)
 		os.Exit(1)
 	}
 
+	// Register the namespaced Ride and RideOperator handlers, which manage
+	// them the same way as their cluster-scoped counterparts
+	if err := builder.RegisterHandler(
+		themeparkmn3wscottcomv1alpha1.RideGroupVersionKind,
+		&ride.ConnectorWrapper{
+			Log:            log.WithValues("handler", "Ride.themepark.m.n3wscott.com"),
+			Client:         kube,
+			Cache:          rideCache,
+			Catalog:        rideTypes,
+			Requeue:        requeuer,
+			MaxWaitMinutes: maxWaitMinutes,
+		},
+	); err != nil {
+		log.Info("Failed to register namespaced Ride handler", "error", err)
+		os.Exit(1)
+	}
+
+	if err := builder.RegisterHandler(
+		themeparkmn3wscottcomv1alpha1.RideOperatorGroupVersionKind,
+		&rideoperator.ConnectorWrapper{
+			Log:     log.WithValues("handler", "RideOperator.themepark.m.n3wscott.com"),
+			Client:  kube,
+			Requeue: requeuer,
+		},
+	); err != nil {
+		log.Info("Failed to register namespaced RideOperator handler", "error", err)
+		os.Exit(1)
+	}
+
 	// Start the gRPC server
 	if err := builder.Start(ctx); err != nil {
 		log.Info("Failed to start gRPC server", "error", err)
@@ -22,13 +22,34 @@ import (
 	"time"
 
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/dynamic"
+
+	themeparkmn3wscottcomv1alpha1 "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
+	themeparkn3wscottcomv1alpha1 "github.com/n3wscott/theme-park-provider/api/v1alpha1"
+	themeparkn3wscottcomv1beta1 "github.com/n3wscott/theme-park-provider/api/v1beta1"
+	providerconfig "github.com/n3wscott/theme-park-provider/pkg/reconciler/config"
+	themeparkwebhook "github.com/n3wscott/theme-park-provider/pkg/webhook"
 )
 
+var s = runtime.NewScheme()
+
+func init() {
+	// Initialize the scheme with Kubernetes types
+	_ = clientgoscheme.AddToScheme(s)
+	// Add custom API types
+	_ = themeparkn3wscottcomv1alpha1.AddToScheme(s)
+	_ = themeparkn3wscottcomv1beta1.AddToScheme(s)
+	_ = themeparkmn3wscottcomv1alpha1.AddToScheme(s)
+}
+
 func main() {
 	var (
 		configPath        string
@@ -40,6 +61,7 @@ func main() {
 		metricsAddr       string
 		probeAddr         string
 		certDir           string
+		webhookPort       int
 	)
 
 	pflag.StringVar(&configPath, "config", "", "Path to the configuration file")
@@ -50,7 +72,8 @@ func main() {
 	pflag.DurationVar(&pollInterval, "poll-interval", 1*time.Minute, "How often a managed resource should be polled when in a steady state")
 	pflag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to")
 	pflag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to")
-	pflag.StringVar(&certDir, "cert-dir", "", "The directory containing TLS certificates")
+	pflag.StringVar(&certDir, "cert-dir", "", "The directory containing TLS certificates. Webhooks are only served when it is set")
+	pflag.IntVar(&webhookPort, "webhook-port", 9443, "The port the webhook server binds to")
 
 	// Add controller-runtime flags
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
@@ -105,6 +128,41 @@ func main() {
 
 	ctx := ctrl.SetupSignalHandler()
 
+	// The dynamic controller only reconciles managed resources, so
+	// ProviderConfigs are reconciled by a manager of their own. This is what
+	// prevents a ProviderConfig that is still in use from being deleted. The
+	// same manager serves the webhooks, which every replica serves whether or
+	// not it is the leader.
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+		Scheme:           s,
+		LeaderElection:   leaderElection,
+		LeaderElectionID: "providerconfig.themepark.n3wscott.com",
+		Metrics:          metricsserver.Options{BindAddress: "0"},
+		WebhookServer:    webhook.NewServer(webhook.Options{CertDir: certDir, Port: webhookPort}),
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to create ProviderConfig manager")
+		os.Exit(1)
+	}
+	if err := providerconfig.Setup(mgr, zapLogger); err != nil {
+		setupLog.Error(err, "unable to setup ProviderConfig controller")
+		os.Exit(1)
+	}
+	if certDir != "" {
+		if err := themeparkwebhook.Setup(mgr); err != nil {
+			setupLog.Error(err, "unable to setup webhooks")
+			os.Exit(1)
+		}
+	} else {
+		setupLog.Info("Not serving webhooks because --cert-dir is not set. Only v1alpha1 Rides and RideOperators can be used without the conversion webhook")
+	}
+	go func() {
+		if err := mgr.Start(ctx); err != nil {
+			setupLog.Error(err, "problem running ProviderConfig manager")
+			os.Exit(1)
+		}
+	}()
+
 	// Setup the controller
 	if err := controller.Setup(ctx); err != nil {
 		setupLog.Error(err, "unable to setup controller")
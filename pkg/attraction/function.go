@@ -0,0 +1,286 @@
+// Package attraction is a composition function that composes an Attraction
+// from a Ride and the RideOperators needed to run it.
+package attraction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	fnv1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/request"
+	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/crossplane/function-sdk-go/resource/composed"
+	"github.com/crossplane/function-sdk-go/response"
+
+	namespaced "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/pkg/catalog"
+)
+
+// TypeTargetMet indicates whether the Ride of an Attraction carries the riders
+// per hour the Attraction asks for.
+const TypeTargetMet = "TargetMet"
+
+// Reasons an Attraction does or does not meet its target.
+const (
+	ReasonTargetMet    = "TargetMet"
+	ReasonBelowTarget  = "BelowTarget"
+	ReasonNotReporting = "NotReporting"
+)
+
+// RideName is the name of the composed Ride of an Attraction.
+const RideName resource.Name = "ride"
+
+// maxFrequency is the most times per hour a RideOperator can operate a ride.
+const maxFrequency = 60
+
+func init() {
+	// composed.From needs to know the kinds of the Rides and RideOperators it
+	// composes.
+	_ = v1alpha1.AddToScheme(composed.Scheme)
+	_ = namespaced.AddToScheme(composed.Scheme)
+}
+
+// AttractionSpec is the desired state of an Attraction.
+type AttractionSpec struct {
+	// RideType is the type of the Ride, which must be in the ride catalog.
+	RideType string `json:"rideType"`
+
+	// RidersPerHour is the throughput the Ride should reach.
+	RidersPerHour int `json:"ridersPerHour"`
+
+	// Capacity is the riders per trip supported on the Ride. Defaults to the
+	// capacity of the ride type.
+	Capacity *int `json:"capacity,omitempty"`
+
+	// ParkRef references the Park the Ride is in.
+	ParkRef *xpv1.Reference `json:"parkRef,omitempty"`
+
+	// Shifts the Ride is staffed for. Each shift gets a crew of its own that
+	// can reach RidersPerHour alone. Staffed by one crew that is always on
+	// shift when unset.
+	Shifts []v1alpha1.WeeklyWindow `json:"shifts,omitempty"`
+
+	// TimeZone of the shifts. Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// Function composes Attractions.
+type Function struct {
+	fnv1.UnimplementedFunctionRunnerServiceServer
+
+	Log logging.Logger
+
+	// RideTypes are the types of ride an Attraction can be composed of.
+	// Defaults to catalog.Default if nil.
+	RideTypes catalog.Catalog
+}
+
+// RunFunction composes the Ride of the observed Attraction, and a crew of
+// RideOperators for each of its shifts whose frequencies add up to the riders
+// per hour it asks for. It reports the riders per hour of the observed Ride,
+// and whether they meet the target, in the status of the Attraction.
+func (f *Function) RunFunction(_ context.Context, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	log := f.Log
+	if log == nil {
+		log = logging.NewNopLogger()
+	}
+	rideTypes := f.RideTypes
+	if rideTypes == nil {
+		rideTypes = catalog.Default
+	}
+	log = log.WithValues("tag", req.GetMeta().GetTag())
+	log.Debug("Running function")
+
+	rsp := response.To(req, response.DefaultTTL)
+
+	oxr, err := request.GetObservedCompositeResource(req)
+	if err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot get observed Attraction"))
+		return rsp, nil
+	}
+	spec := AttractionSpec{}
+	if err := oxr.Resource.GetValueInto("spec", &spec); err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot get Attraction spec"))
+		return rsp, nil
+	}
+	t, ok := rideTypes.Lookup(spec.RideType)
+	if !ok {
+		response.Fatal(rsp, errors.Errorf("unknown ride type %q", spec.RideType))
+		return rsp, nil
+	}
+	if spec.RidersPerHour <= 0 {
+		response.Fatal(rsp, errors.New("ridersPerHour must be positive"))
+		return rsp, nil
+	}
+	capacity := ptr.Deref(spec.Capacity, t.Capacity)
+	if capacity <= 0 {
+		response.Fatal(rsp, errors.Errorf("ride type %q has no capacity", spec.RideType))
+		return rsp, nil
+	}
+
+	dispatches := (spec.RidersPerHour + capacity - 1) / capacity
+	if limit := t.MaxDispatchesPerHour(); limit > 0 && dispatches > limit {
+		response.Warning(rsp, errors.Errorf("a %s carrying %d riders per trip can carry at most %d riders per hour", spec.RideType, capacity, capacity*limit)).
+			TargetCompositeAndClaim()
+		dispatches = limit
+	}
+	crew := frequencies(dispatches, t.MinimumCrew)
+
+	desired, err := request.GetDesiredComposedResources(req)
+	if err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot get desired composed resources"))
+		return rsp, nil
+	}
+
+	ns := oxr.Resource.GetNamespace()
+	r, err := composed.From(rideObject(ns, v1alpha1.RideParameters{
+		Type:     spec.RideType,
+		Capacity: spec.Capacity,
+		ParkRef:  spec.ParkRef,
+	}))
+	if err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot compose Ride"))
+		return rsp, nil
+	}
+	desired[RideName] = &resource.DesiredComposed{Resource: r}
+
+	// An empty shift pattern means one crew that is always on shift.
+	shifts := [][]v1alpha1.WeeklyWindow{nil}
+	if len(spec.Shifts) > 0 {
+		shifts = make([][]v1alpha1.WeeklyWindow, 0, len(spec.Shifts))
+		for _, s := range spec.Shifts {
+			shifts = append(shifts, []v1alpha1.WeeklyWindow{s})
+		}
+	}
+	certs := make([]v1alpha1.Certification, 0, len(t.Certifications))
+	for _, c := range t.Certifications {
+		certs = append(certs, v1alpha1.Certification{Name: c})
+	}
+	for s := range shifts {
+		for i, freq := range crew {
+			ro, err := composed.From(operatorObject(ns, v1alpha1.RideOperatorParameters{
+				Frequency:      freq,
+				Certifications: certs,
+				Shifts:         shifts[s],
+				TimeZone:       spec.TimeZone,
+				// The Ride is the only one controlled by the Attraction.
+				RideSelector: &xpv1.Selector{MatchControllerRef: ptr.To(true)},
+			}))
+			if err != nil {
+				response.Fatal(rsp, errors.Wrap(err, "cannot compose RideOperator"))
+				return rsp, nil
+			}
+			desired[operatorName(s, i)] = &resource.DesiredComposed{Resource: ro}
+		}
+	}
+
+	if err := response.SetDesiredComposedResources(rsp, desired); err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot set desired composed resources"))
+		return rsp, nil
+	}
+
+	observed, err := request.GetObservedComposedResources(req)
+	if err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot get observed composed resources"))
+		return rsp, nil
+	}
+	dxr, err := request.GetDesiredCompositeResource(req)
+	if err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot get desired Attraction"))
+		return rsp, nil
+	}
+
+	// The Ride reports the riders per hour it carries once the provider has
+	// observed it.
+	ridersPerHour, reporting := int64(0), false
+	if or, ok := observed[RideName]; ok {
+		if v, err := or.Resource.GetInteger("status.ridersPerHour"); err == nil {
+			ridersPerHour, reporting = v, true
+		}
+	}
+	if err := dxr.Resource.SetValue("status.ridersPerHour", ridersPerHour); err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot set Attraction riders per hour"))
+		return rsp, nil
+	}
+	if err := dxr.Resource.SetValue("status.operators", len(shifts)*len(crew)); err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot set Attraction operators"))
+		return rsp, nil
+	}
+	if err := response.SetDesiredCompositeResource(rsp, dxr); err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot set desired Attraction"))
+		return rsp, nil
+	}
+
+	switch {
+	case !reporting:
+		response.ConditionFalse(rsp, TypeTargetMet, ReasonNotReporting).
+			WithMessage("Ride is not reporting its riders per hour yet").
+			TargetCompositeAndClaim()
+	case ridersPerHour < int64(spec.RidersPerHour):
+		response.ConditionFalse(rsp, TypeTargetMet, ReasonBelowTarget).
+			WithMessage(fmt.Sprintf("Ride carries %d of %d riders per hour", ridersPerHour, spec.RidersPerHour)).
+			TargetCompositeAndClaim()
+	default:
+		response.ConditionTrue(rsp, TypeTargetMet, ReasonTargetMet).
+			WithMessage(fmt.Sprintf("Ride carries %d riders per hour", ridersPerHour)).
+			TargetCompositeAndClaim()
+	}
+
+	return rsp, nil
+}
+
+// frequencies returns the frequency of each operator in the smallest crew of
+// at least the supplied minimum size that can dispatch a ride the supplied
+// number of times per hour. Dispatches are spread as evenly as possible, and
+// every operator operates the ride at least once per hour.
+func frequencies(dispatches, minimumCrew int) []int {
+	n := max((dispatches+maxFrequency-1)/maxFrequency, minimumCrew, 1)
+	crew := make([]int, n)
+	for i := range crew {
+		crew[i] = dispatches / n
+		if i < dispatches%n {
+			crew[i]++
+		}
+		crew[i] = max(crew[i], 1)
+	}
+	return crew
+}
+
+// operatorName returns the name of the composed RideOperator at the supplied
+// position in the crew of the supplied shift.
+func operatorName(shift, i int) resource.Name {
+	return resource.Name(fmt.Sprintf("operator-%d-%d", shift, i))
+}
+
+// rideObject returns a Ride with the supplied parameters, which is a
+// namespaced Ride if the Attraction is in a namespace.
+func rideObject(namespace string, p v1alpha1.RideParameters) runtime.Object {
+	if namespace == "" {
+		r := &v1alpha1.Ride{Spec: v1alpha1.RideSpec{ForProvider: p}}
+		r.SetGroupVersionKind(v1alpha1.RideGroupVersionKind)
+		return r
+	}
+	r := &namespaced.Ride{Spec: namespaced.RideSpec{ForProvider: namespaced.RideParameters(p)}}
+	r.SetGroupVersionKind(namespaced.RideGroupVersionKind)
+	return r
+}
+
+// operatorObject returns a RideOperator with the supplied parameters, which is
+// a namespaced RideOperator if the Attraction is in a namespace.
+func operatorObject(namespace string, p v1alpha1.RideOperatorParameters) runtime.Object {
+	if namespace == "" {
+		ro := &v1alpha1.RideOperator{Spec: v1alpha1.RideOperatorSpec{ForProvider: p}}
+		ro.SetGroupVersionKind(v1alpha1.RideOperatorGroupVersionKind)
+		return ro
+	}
+	ro := &namespaced.RideOperator{Spec: namespaced.RideOperatorSpec{ForProvider: namespaced.RideOperatorParameters(p)}}
+	ro.SetGroupVersionKind(namespaced.RideOperatorGroupVersionKind)
+	return ro
+}
@@ -0,0 +1,226 @@
+package attraction
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	fnv1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/crossplane/function-sdk-go/resource/composed"
+
+	"github.com/n3wscott/theme-park-provider/pkg/catalog"
+)
+
+func TestRunFunction(t *testing.T) {
+	rideTypes := catalog.Catalog{
+		"droptower":     {Capacity: 10},
+		"logflume":      catalog.Default["logflume"],
+		"rollercoaster": catalog.Default["rollercoaster"],
+	}
+
+	cases := map[string]struct {
+		xr          *fnv1.Resource
+		apiVersion  string
+		frequencies map[resource.Name]int64
+		fatal       bool
+		warning     bool
+	}{
+		"AlwaysOnShift": {
+			xr:          newAttraction("", `{"rideType": "rollercoaster", "ridersPerHour": 480}`),
+			apiVersion:  "themepark.n3wscott.com/v1alpha1",
+			frequencies: map[resource.Name]int64{"operator-0-0": 20},
+		},
+		"SharedDispatches": {
+			xr:          newAttraction("", `{"rideType": "droptower", "ridersPerHour": 1250}`),
+			apiVersion:  "themepark.n3wscott.com/v1alpha1",
+			frequencies: map[resource.Name]int64{"operator-0-0": 42, "operator-0-1": 42, "operator-0-2": 41},
+		},
+		"CrewPerShift": {
+			xr: newAttraction("", `{"rideType": "logflume", "ridersPerHour": 40, "shifts": [
+				{"start": "09:00", "end": "15:00"},
+				{"start": "15:00", "end": "21:00"}
+			]}`),
+			apiVersion:  "themepark.n3wscott.com/v1alpha1",
+			frequencies: map[resource.Name]int64{"operator-0-0": 5, "operator-0-1": 5, "operator-1-0": 5, "operator-1-1": 5},
+		},
+		"MinimumCrew": {
+			xr:          newAttraction("", `{"rideType": "logflume", "ridersPerHour": 4}`),
+			apiVersion:  "themepark.n3wscott.com/v1alpha1",
+			frequencies: map[resource.Name]int64{"operator-0-0": 1, "operator-0-1": 1},
+		},
+		"Unreachable": {
+			xr:          newAttraction("", `{"rideType": "rollercoaster", "ridersPerHour": 1000}`),
+			apiVersion:  "themepark.n3wscott.com/v1alpha1",
+			frequencies: map[resource.Name]int64{"operator-0-0": 30},
+			warning:     true,
+		},
+		"Namespaced": {
+			xr:          newAttraction("team-a", `{"rideType": "rollercoaster", "ridersPerHour": 240}`),
+			apiVersion:  "themepark.m.n3wscott.com/v1alpha1",
+			frequencies: map[resource.Name]int64{"operator-0-0": 10},
+		},
+		"UnknownRideType": {
+			xr:    newAttraction("", `{"rideType": "bumpercars", "ridersPerHour": 240}`),
+			fatal: true,
+		},
+		"NoTarget": {
+			xr:    newAttraction("", `{"rideType": "rollercoaster"}`),
+			fatal: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := &Function{RideTypes: rideTypes}
+			rsp, err := f.RunFunction(context.Background(), &fnv1.RunFunctionRequest{
+				Observed: &fnv1.State{Composite: tc.xr},
+			})
+			if err != nil {
+				t.Fatalf("RunFunction(...): %v", err)
+			}
+			if got := hasResult(rsp, fnv1.Severity_SEVERITY_FATAL); got != tc.fatal {
+				t.Fatalf("RunFunction(...): want fatal result %t, got %v", tc.fatal, rsp.GetResults())
+			}
+			if tc.fatal {
+				return
+			}
+			if got := hasResult(rsp, fnv1.Severity_SEVERITY_WARNING); got != tc.warning {
+				t.Errorf("RunFunction(...): want warning result %t, got %v", tc.warning, rsp.GetResults())
+			}
+
+			got := map[resource.Name]int64{}
+			for n, r := range rsp.GetDesired().GetResources() {
+				cd := composed.New()
+				if err := resource.AsObject(r.GetResource(), cd); err != nil {
+					t.Fatalf("AsObject(%s): %v", n, err)
+				}
+				if cd.GetAPIVersion() != tc.apiVersion {
+					t.Errorf("%s: want apiVersion %s, got %s", n, tc.apiVersion, cd.GetAPIVersion())
+				}
+				if resource.Name(n) == RideName {
+					if cd.GetKind() != "Ride" {
+						t.Errorf("%s: want a Ride, got a %s", n, cd.GetKind())
+					}
+					continue
+				}
+				freq, err := cd.GetInteger("spec.forProvider.frequency")
+				if err != nil {
+					t.Fatalf("%s: %v", n, err)
+				}
+				got[resource.Name(n)] = freq
+			}
+			if _, ok := rsp.GetDesired().GetResources()[string(RideName)]; !ok {
+				t.Errorf("RunFunction(...): want a desired Ride")
+			}
+			if diff := cmp.Diff(tc.frequencies, got); diff != "" {
+				t.Errorf("RunFunction(...): -want operator frequencies, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRunFunctionTargetMet(t *testing.T) {
+	cases := map[string]struct {
+		ride          *fnv1.Resource
+		status        fnv1.Status
+		reason        string
+		ridersPerHour float64
+	}{
+		"NotObserved": {
+			status: fnv1.Status_STATUS_CONDITION_FALSE,
+			reason: ReasonNotReporting,
+		},
+		"NotReporting": {
+			ride:   newRide(`{}`),
+			status: fnv1.Status_STATUS_CONDITION_FALSE,
+			reason: ReasonNotReporting,
+		},
+		"BelowTarget": {
+			ride:          newRide(`{"ridersPerHour": 240}`),
+			status:        fnv1.Status_STATUS_CONDITION_FALSE,
+			reason:        ReasonBelowTarget,
+			ridersPerHour: 240,
+		},
+		"TargetMet": {
+			ride:          newRide(`{"ridersPerHour": 480}`),
+			status:        fnv1.Status_STATUS_CONDITION_TRUE,
+			reason:        ReasonTargetMet,
+			ridersPerHour: 480,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := &fnv1.RunFunctionRequest{
+				Observed: &fnv1.State{
+					Composite: newAttraction("", `{"rideType": "rollercoaster", "ridersPerHour": 480}`),
+				},
+			}
+			if tc.ride != nil {
+				req.Observed.Resources = map[string]*fnv1.Resource{string(RideName): tc.ride}
+			}
+
+			rsp, err := (&Function{}).RunFunction(context.Background(), req)
+			if err != nil {
+				t.Fatalf("RunFunction(...): %v", err)
+			}
+
+			var c *fnv1.Condition
+			for _, cc := range rsp.GetConditions() {
+				if cc.GetType() == TypeTargetMet {
+					c = cc
+				}
+			}
+			if c == nil {
+				t.Fatalf("RunFunction(...): want a %s condition, got %v", TypeTargetMet, rsp.GetConditions())
+			}
+			if c.GetStatus() != tc.status || c.GetReason() != tc.reason {
+				t.Errorf("RunFunction(...): want %s %s, got %s %s", tc.status, tc.reason, c.GetStatus(), c.GetReason())
+			}
+
+			status := rsp.GetDesired().GetComposite().GetResource().GetFields()["status"].GetStructValue().GetFields()
+			if got := status["ridersPerHour"].GetNumberValue(); got != tc.ridersPerHour {
+				t.Errorf("RunFunction(...): want status.ridersPerHour %v, got %v", tc.ridersPerHour, got)
+			}
+			if got := status["operators"].GetNumberValue(); got != 1 {
+				t.Errorf("RunFunction(...): want status.operators 1, got %v", got)
+			}
+		})
+	}
+}
+
+// newAttraction returns an observed Attraction in the supplied namespace with
+// the supplied spec.
+func newAttraction(namespace, spec string) *fnv1.Resource {
+	return &fnv1.Resource{Resource: resource.MustStructJSON(fmt.Sprintf(`{
+		"apiVersion": "platform.themepark.n3wscott.com/v1alpha1",
+		"kind": "Attraction",
+		"metadata": {"name": "coaster", "namespace": %q},
+		"spec": %s
+	}`, namespace, spec))}
+}
+
+// newRide returns an observed Ride with the supplied status.
+func newRide(status string) *fnv1.Resource {
+	return &fnv1.Resource{Resource: resource.MustStructJSON(fmt.Sprintf(`{
+		"apiVersion": "themepark.n3wscott.com/v1alpha1",
+		"kind": "Ride",
+		"metadata": {"name": "coaster-x7k2p"},
+		"spec": {"forProvider": {"type": "rollercoaster"}},
+		"status": %s
+	}`, status))}
+}
+
+// hasResult returns true if the supplied response has a result of the supplied
+// severity.
+func hasResult(rsp *fnv1.RunFunctionResponse, s fnv1.Severity) bool {
+	for _, r := range rsp.GetResults() {
+		if r.GetSeverity() == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+// Package catalog describes the types of ride a park can build.
+package catalog
+
+import (
+	"os"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// A RideType describes a model of ride.
+type RideType struct {
+	// Capacity is the riders per trip the ride type supports.
+	Capacity int `json:"capacity"`
+
+	// CycleTime is how long one trip takes, including loading and unloading.
+	// A ride can be dispatched at most once per cycle.
+	CycleTime metav1.Duration `json:"cycleTime,omitempty"`
+
+	// MinimumCrew is the number of operators required to run the ride type.
+	MinimumCrew int `json:"minimumCrew,omitempty"`
+
+	// Certifications are those an operator must hold to operate the ride
+	// type.
+	Certifications []string `json:"certifications,omitempty"`
+}
+
+// MaxDispatchesPerHour returns how many times per hour a ride of this type can
+// be dispatched, or 0 if there is no limit.
+func (t RideType) MaxDispatchesPerHour() int {
+	if t.CycleTime.Duration <= 0 {
+		return 0
+	}
+	return int(time.Hour / t.CycleTime.Duration)
+}
+
+// A Catalog of ride types, keyed by the type name used in a Ride's spec.
+type Catalog map[string]RideType
+
+// Default is the catalog of ride types every park can build.
+var Default = Catalog{
+	"carousel": {
+		Capacity:  40,
+		CycleTime: metav1.Duration{Duration: 5 * time.Minute},
+	},
+	"ferriswheel": {
+		Capacity:  8,
+		CycleTime: metav1.Duration{Duration: time.Minute},
+	},
+	"logflume": {
+		Capacity:       4,
+		CycleTime:      metav1.Duration{Duration: time.Minute},
+		MinimumCrew:    2,
+		Certifications: []string{"water-safety"},
+	},
+	"rollercoaster": {
+		Capacity:       24,
+		CycleTime:      metav1.Duration{Duration: 2 * time.Minute},
+		Certifications: []string{"coaster-operations"},
+	},
+	"teacups": {
+		Capacity:  36,
+		CycleTime: metav1.Duration{Duration: 3 * time.Minute},
+	},
+}
+
+// Lookup returns the ride type with the supplied name, if it is in the
+// catalog.
+func (c Catalog) Lookup(name string) (RideType, bool) {
+	t, ok := c[name]
+	return t, ok
+}
+
+// Names returns the sorted names of every ride type in the catalog.
+func (c Catalog) Names() []string {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load returns the Default catalog extended with the ride types in the
+// supplied YAML file. A type in the file replaces the built-in type of the same
+// name. The file maps type names to ride types, e.g.
+//
+//	rollercoaster:
+//	  capacity: 24
+//	  cycleTime: 2m
+//	  minimumCrew: 2
+//	  certifications: [coaster-operations]
+func Load(path string) (Catalog, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read ride catalog")
+	}
+	types := Catalog{}
+	if err := yaml.UnmarshalStrict(b, &types); err != nil {
+		return nil, errors.Wrap(err, "cannot parse ride catalog")
+	}
+
+	c := make(Catalog, len(Default)+len(types))
+	for name, t := range Default {
+		c[name] = t
+	}
+	for name, t := range types {
+		if err := t.validate(); err != nil {
+			return nil, errors.Wrapf(err, "invalid ride type %q", name)
+		}
+		c[name] = t
+	}
+	return c, nil
+}
+
+func (t RideType) validate() error {
+	switch {
+	case t.Capacity <= 0:
+		return errors.New("capacity must be positive")
+	case t.CycleTime.Duration < 0:
+		return errors.New("cycleTime must not be negative")
+	case t.MinimumCrew < 0:
+		return errors.New("minimumCrew must not be negative")
+	}
+	return nil
+}
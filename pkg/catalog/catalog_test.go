@@ -0,0 +1,69 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.yaml")
+	if err := os.WriteFile(path, []byte(`
+rollercoaster:
+  capacity: 32
+  cycleTime: 90s
+  minimumCrew: 2
+  certifications: [coaster-operations]
+droptower:
+  capacity: 12
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load(...): %v", err)
+	}
+
+	rc, ok := c.Lookup("rollercoaster")
+	if !ok {
+		t.Fatalf("Lookup(rollercoaster): want a ride type")
+	}
+	if rc.Capacity != 32 || rc.MinimumCrew != 2 || rc.CycleTime.Duration != 90*time.Second {
+		t.Errorf("Lookup(rollercoaster): want the type from the file, got %+v", rc)
+	}
+	if got := rc.MaxDispatchesPerHour(); got != 40 {
+		t.Errorf("MaxDispatchesPerHour(): want 40, got %d", got)
+	}
+	if _, ok := c.Lookup("droptower"); !ok {
+		t.Errorf("Lookup(droptower): want a ride type added by the file")
+	}
+	if _, ok := c.Lookup("carousel"); !ok {
+		t.Errorf("Lookup(carousel): want the built-in ride type")
+	}
+	if _, ok := Default["droptower"]; ok {
+		t.Errorf("Load(...): must not modify the Default catalog")
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	cases := map[string]string{
+		"UnknownField":     "rollercoaster:\n  capacity: 24\n  speed: 90\n",
+		"NoCapacity":       "rollercoaster:\n  minimumCrew: 2\n",
+		"NegativeCrew":     "rollercoaster:\n  capacity: 24\n  minimumCrew: -1\n",
+		"NotAMapOfTypes":   "- rollercoaster\n",
+		"InvalidCycleTime": "rollercoaster:\n  capacity: 24\n  cycleTime: soon\n",
+	}
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "catalog.yaml")
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Load(path); err == nil {
+				t.Errorf("Load(...): want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package park
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Client talks to a ride control system over its HTTP API.
+type Client struct {
+	endpoint string
+	token    string
+	http     *http.Client
+}
+
+// A ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithHTTPClient configures the HTTP client used to talk to the control
+// system. http.DefaultClient is used by default.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.http = hc
+	}
+}
+
+// WithBearerToken configures the client to authenticate using the supplied
+// bearer token.
+func WithBearerToken(token string) ClientOption {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// NewClient returns a Client for the control system served at the supplied
+// endpoint, e.g. http://localhost:8090.
+func NewClient(endpoint string, opts ...ClientOption) *Client {
+	c := &Client{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		http:     http.DefaultClient,
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// RideEndpoint returns the URL of the ride with the supplied ID, which can be
+// accessed using the ride's control token.
+func (c *Client) RideEndpoint(id string) string {
+	return c.endpoint + "/rides/" + url.PathEscape(id)
+}
+
+// OperatorEndpoint returns the URL of the operator with the supplied ID, which
+// the operator can read using their console token.
+func (c *Client) OperatorEndpoint(id string) string {
+	return c.endpoint + "/operators/" + url.PathEscape(id)
+}
+
+// ListParks returns all parks known to the control system.
+func (c *Client) ListParks(ctx context.Context) ([]Park, error) {
+	var parks []Park
+	err := c.do(ctx, http.MethodGet, "/parks", nil, &parks)
+	return parks, err
+}
+
+// GetPark returns the park with the supplied ID.
+func (c *Client) GetPark(ctx context.Context, id string) (*Park, error) {
+	p := &Park{}
+	if err := c.do(ctx, http.MethodGet, "/parks/"+url.PathEscape(id), nil, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// CreatePark creates the supplied park. An ID is generated by the control
+// system if the park does not have one.
+func (c *Client) CreatePark(ctx context.Context, p Park) (*Park, error) {
+	out := &Park{}
+	if err := c.do(ctx, http.MethodPost, "/parks", p, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdatePark replaces the park with the supplied park's ID.
+func (c *Client) UpdatePark(ctx context.Context, p Park) (*Park, error) {
+	out := &Park{}
+	if err := c.do(ctx, http.MethodPut, "/parks/"+url.PathEscape(p.ID), p, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeletePark deletes the park with the supplied ID.
+func (c *Client) DeletePark(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/parks/"+url.PathEscape(id), nil, nil)
+}
+
+// ListRides returns all rides known to the control system.
+func (c *Client) ListRides(ctx context.Context) ([]Ride, error) {
+	var rides []Ride
+	err := c.do(ctx, http.MethodGet, "/rides", nil, &rides)
+	return rides, err
+}
+
+// GetRide returns the ride with the supplied ID.
+func (c *Client) GetRide(ctx context.Context, id string) (*Ride, error) {
+	r := &Ride{}
+	if err := c.do(ctx, http.MethodGet, "/rides/"+url.PathEscape(id), nil, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// CreateRide creates the supplied ride. An ID is generated by the control
+// system if the ride does not have one.
+func (c *Client) CreateRide(ctx context.Context, r Ride) (*Ride, error) {
+	out := &Ride{}
+	if err := c.do(ctx, http.MethodPost, "/rides", r, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateRide replaces the ride with the supplied ride's ID.
+func (c *Client) UpdateRide(ctx context.Context, r Ride) (*Ride, error) {
+	out := &Ride{}
+	if err := c.do(ctx, http.MethodPut, "/rides/"+url.PathEscape(r.ID), r, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SetQueueLength sets the number of guests queuing for the ride with the
+// supplied ID.
+func (c *Client) SetQueueLength(ctx context.Context, id string, length int) (*Ride, error) {
+	out := &Ride{}
+	if err := c.do(ctx, http.MethodPut, "/rides/"+url.PathEscape(id)+"/queue", Queue{Length: length}, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InjectFault breaks down the ride with the supplied ID with a fault of the
+// supplied kind. The ride stays broken down until ClearFault is called.
+func (c *Client) InjectFault(ctx context.Context, id string, kind FaultKind, message string) (*Ride, error) {
+	out := &Ride{}
+	if err := c.do(ctx, http.MethodPost, "/rides/"+url.PathEscape(id)+"/faults", Fault{Kind: kind, Message: message}, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClearFault repairs the ride with the supplied ID, if it is broken down.
+func (c *Client) ClearFault(ctx context.Context, id string) (*Ride, error) {
+	out := &Ride{}
+	if err := c.do(ctx, http.MethodDelete, "/rides/"+url.PathEscape(id)+"/faults", nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteRide deletes the ride with the supplied ID. Any operators assigned to
+// it are unassigned.
+func (c *Client) DeleteRide(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/rides/"+url.PathEscape(id), nil, nil)
+}
+
+// ListOperators returns all operators known to the control system. If rideID
+// is not empty only operators assigned to that ride are returned.
+func (c *Client) ListOperators(ctx context.Context, rideID string) ([]Operator, error) {
+	path := "/operators"
+	if rideID != "" {
+		path += "?ride=" + url.QueryEscape(rideID)
+	}
+	var operators []Operator
+	err := c.do(ctx, http.MethodGet, path, nil, &operators)
+	return operators, err
+}
+
+// GetOperator returns the operator with the supplied ID.
+func (c *Client) GetOperator(ctx context.Context, id string) (*Operator, error) {
+	o := &Operator{}
+	if err := c.do(ctx, http.MethodGet, "/operators/"+url.PathEscape(id), nil, o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// CreateOperator creates the supplied operator. An ID is generated by the
+// control system if the operator does not have one.
+func (c *Client) CreateOperator(ctx context.Context, o Operator) (*Operator, error) {
+	out := &Operator{}
+	if err := c.do(ctx, http.MethodPost, "/operators", o, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateOperator replaces the operator with the supplied operator's ID.
+func (c *Client) UpdateOperator(ctx context.Context, o Operator) (*Operator, error) {
+	out := &Operator{}
+	if err := c.do(ctx, http.MethodPut, "/operators/"+url.PathEscape(o.ID), o, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RotateConsoleToken issues a new console token for the operator with the
+// supplied ID. Their previous token remains valid for the supplied overlap.
+func (c *Client) RotateConsoleToken(ctx context.Context, id string, overlap time.Duration) (*Operator, error) {
+	out := &Operator{}
+	if err := c.do(ctx, http.MethodPost, "/operators/"+url.PathEscape(id)+"/console/rotate", Rotation{Overlap: overlap}, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteOperator deletes the operator with the supplied ID.
+func (c *Client) DeleteOperator(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/operators/"+url.PathEscape(id), nil, nil)
+}
+
+// do sends a request with the supplied body, if any, and decodes the response
+// into out, if any.
+func (c *Client) do(ctx context.Context, method, path string, in, out any) error {
+	var body bytes.Buffer
+	if in != nil {
+		if err := json.NewEncoder(&body).Encode(in); err != nil {
+			return errors.Wrap(err, "cannot encode request")
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, &body)
+	if err != nil {
+		return errors.Wrap(err, "cannot create request")
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	rsp, err := c.http.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "cannot %s %s", method, path)
+	}
+	defer rsp.Body.Close() //nolint:errcheck // Nothing useful to do with this error.
+
+	if rsp.StatusCode >= http.StatusBadRequest {
+		e := errorResponse{}
+		_ = json.NewDecoder(rsp.Body).Decode(&e)
+		switch rsp.StatusCode {
+		case http.StatusNotFound:
+			return &statusError{cause: ErrNotFound, message: e.Error}
+		case http.StatusConflict:
+			return &statusError{cause: ErrConflict, message: e.Error}
+		default:
+			return errors.Errorf("cannot %s %s: %s: %s", method, path, rsp.Status, e.Error)
+		}
+	}
+
+	if out == nil || rsp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return errors.Wrap(json.NewDecoder(rsp.Body).Decode(out), "cannot decode response")
+}
+
+// A statusError is an error returned by the control system that callers can
+// test for using IsNotFound or IsConflict.
+type statusError struct {
+	cause   error
+	message string
+}
+
+func (e *statusError) Error() string { return e.message }
+
+func (e *statusError) Unwrap() error { return e.cause }
@@ -0,0 +1,327 @@
+package park
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRideLifecycle(t *testing.T) {
+	srv := httptest.NewServer(NewServer())
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewClient(srv.URL)
+
+	if _, err := c.GetRide(ctx, "coaster"); !IsNotFound(err) {
+		t.Fatalf("GetRide(...): want not found error, got %v", err)
+	}
+
+	created, err := c.CreateRide(ctx, Ride{ID: "coaster", Name: "coaster", Type: "rollercoaster", Capacity: 24})
+	if err != nil {
+		t.Fatalf("CreateRide(...): %v", err)
+	}
+	if _, err := c.CreateRide(ctx, *created); !IsConflict(err) {
+		t.Fatalf("CreateRide(...): want conflict error, got %v", err)
+	}
+
+	created.Capacity = 30
+	if _, err := c.UpdateRide(ctx, *created); err != nil {
+		t.Fatalf("UpdateRide(...): %v", err)
+	}
+	got, err := c.GetRide(ctx, "coaster")
+	if err != nil {
+		t.Fatalf("GetRide(...): %v", err)
+	}
+	if got.Capacity != 30 {
+		t.Errorf("GetRide(...).Capacity: want 30, got %d", got.Capacity)
+	}
+
+	if _, err := c.SetQueueLength(ctx, "coaster", 120); err != nil {
+		t.Fatalf("SetQueueLength(...): %v", err)
+	}
+	if _, err := c.UpdateRide(ctx, *created); err != nil {
+		t.Fatalf("UpdateRide(...): %v", err)
+	}
+	got, err = c.GetRide(ctx, "coaster")
+	if err != nil {
+		t.Fatalf("GetRide(...): %v", err)
+	}
+	if got.QueueLength != 120 {
+		t.Errorf("GetRide(...).QueueLength: want 120 to survive an update, got %d", got.QueueLength)
+	}
+
+	if err := c.DeleteRide(ctx, "coaster"); err != nil {
+		t.Fatalf("DeleteRide(...): %v", err)
+	}
+	if err := c.DeleteRide(ctx, "coaster"); !IsNotFound(err) {
+		t.Fatalf("DeleteRide(...): want not found error, got %v", err)
+	}
+}
+
+func TestParkLifecycle(t *testing.T) {
+	srv := httptest.NewServer(NewServer())
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewClient(srv.URL)
+
+	created, err := c.CreatePark(ctx, Park{Name: "Frontier Land", TimeZone: "America/Los_Angeles"})
+	if err != nil {
+		t.Fatalf("CreatePark(...): %v", err)
+	}
+	if created.ID == "" {
+		t.Fatalf("CreatePark(...): want a generated ID")
+	}
+
+	created.TimeZone = "America/New_York"
+	if _, err := c.UpdatePark(ctx, *created); err != nil {
+		t.Fatalf("UpdatePark(...): %v", err)
+	}
+	parks, err := c.ListParks(ctx)
+	if err != nil {
+		t.Fatalf("ListParks(...): %v", err)
+	}
+	if len(parks) != 1 || parks[0].TimeZone != "America/New_York" {
+		t.Errorf("ListParks(...): want one park in America/New_York, got %+v", parks)
+	}
+
+	if err := c.DeletePark(ctx, created.ID); err != nil {
+		t.Fatalf("DeletePark(...): %v", err)
+	}
+	if _, err := c.GetPark(ctx, created.ID); !IsNotFound(err) {
+		t.Fatalf("GetPark(...): want not found error, got %v", err)
+	}
+}
+
+func TestOperatorAssignment(t *testing.T) {
+	srv := httptest.NewServer(NewServer())
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewClient(srv.URL)
+
+	r, err := c.CreateRide(ctx, Ride{Name: "coaster", Type: "rollercoaster", Capacity: 24})
+	if err != nil {
+		t.Fatalf("CreateRide(...): %v", err)
+	}
+	if r.ID == "" {
+		t.Fatalf("CreateRide(...): want generated ID")
+	}
+
+	for _, o := range []Operator{
+		{ID: "alice", RideID: r.ID, Frequency: 10},
+		{ID: "bob", RideID: r.ID, Frequency: 5},
+		{ID: "carol", Frequency: 5},
+	} {
+		if _, err := c.CreateOperator(ctx, o); err != nil {
+			t.Fatalf("CreateOperator(...): %v", err)
+		}
+	}
+
+	assigned, err := c.ListOperators(ctx, r.ID)
+	if err != nil {
+		t.Fatalf("ListOperators(...): %v", err)
+	}
+	if len(assigned) != 2 {
+		t.Errorf("ListOperators(...): want 2 operators, got %d", len(assigned))
+	}
+
+	if err := c.DeleteRide(ctx, r.ID); err != nil {
+		t.Fatalf("DeleteRide(...): %v", err)
+	}
+	o, err := c.GetOperator(ctx, "alice")
+	if err != nil {
+		t.Fatalf("GetOperator(...): %v", err)
+	}
+	if o.RideID != "" {
+		t.Errorf("GetOperator(...).RideID: want operator unassigned after ride deletion, got %q", o.RideID)
+	}
+}
+
+func TestRideFaults(t *testing.T) {
+	srv := httptest.NewServer(NewServer())
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewClient(srv.URL)
+
+	created, err := c.CreateRide(ctx, Ride{ID: "coaster", Name: "coaster", Type: "rollercoaster", Capacity: 24})
+	if err != nil {
+		t.Fatalf("CreateRide(...): %v", err)
+	}
+
+	if _, err := c.InjectFault(ctx, "coaster", "Meltdown", ""); err == nil {
+		t.Errorf("InjectFault(...): want error for an unknown fault kind")
+	}
+	if _, err := c.InjectFault(ctx, "coaster", FaultEmergencyStop, "guest pressed e-stop"); err != nil {
+		t.Fatalf("InjectFault(...): %v", err)
+	}
+	if _, err := c.UpdateRide(ctx, *created); err != nil {
+		t.Fatalf("UpdateRide(...): %v", err)
+	}
+	got, err := c.GetRide(ctx, "coaster")
+	if err != nil {
+		t.Fatalf("GetRide(...): %v", err)
+	}
+	if !got.LastFault.Active() || got.LastFault.Kind != FaultEmergencyStop || got.Faults != 1 {
+		t.Errorf("GetRide(...): want an active EmergencyStop fault to survive an update, got %+v", got.LastFault)
+	}
+
+	got, err = c.ClearFault(ctx, "coaster")
+	if err != nil {
+		t.Fatalf("ClearFault(...): %v", err)
+	}
+	if got.LastFault.Active() {
+		t.Errorf("ClearFault(...): want the fault cleared, got %+v", got.LastFault)
+	}
+	if got.Downtime <= 0 {
+		t.Errorf("ClearFault(...).Downtime: want downtime, got %s", got.Downtime)
+	}
+}
+
+func TestFaultInjection(t *testing.T) {
+	kinds := make([]FaultKind, 0, 2)
+	for range 2 {
+		srv := httptest.NewServer(NewServer(WithFaultInjection(time.Nanosecond, 42)))
+		c := NewClient(srv.URL)
+		ctx := context.Background()
+
+		if _, err := c.CreateRide(ctx, Ride{ID: "coaster", Name: "coaster", Type: "rollercoaster", Capacity: 24}); err != nil {
+			t.Fatalf("CreateRide(...): %v", err)
+		}
+		got, err := c.GetRide(ctx, "coaster")
+		if err != nil {
+			t.Fatalf("GetRide(...): %v", err)
+		}
+		if !got.LastFault.Active() {
+			t.Fatalf("GetRide(...).LastFault: want an injected fault, got %+v", got.LastFault)
+		}
+		kinds = append(kinds, got.LastFault.Kind)
+		srv.Close()
+	}
+	if kinds[0] != kinds[1] {
+		t.Errorf("WithFaultInjection(...): want the same seed to inject the same faults, got %v", kinds)
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	srv := httptest.NewServer(NewServer(WithToken("s3cr3t")))
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	if _, err := NewClient(srv.URL).ListRides(ctx); err == nil {
+		t.Errorf("ListRides(...): want error without a token")
+	}
+	if _, err := NewClient(srv.URL, WithBearerToken("wrong")).ListRides(ctx); err == nil {
+		t.Errorf("ListRides(...): want error with the wrong token")
+	}
+	if _, err := NewClient(srv.URL, WithBearerToken("s3cr3t")).ListRides(ctx); err != nil {
+		t.Errorf("ListRides(...): %v", err)
+	}
+}
+
+func TestScopedTokens(t *testing.T) {
+	srv := httptest.NewServer(NewServer(WithToken("s3cr3t")))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewClient(srv.URL, WithBearerToken("s3cr3t"))
+
+	coaster, err := c.CreateRide(ctx, Ride{ID: "coaster", Name: "coaster", Type: "rollercoaster", Capacity: 24})
+	if err != nil {
+		t.Fatalf("CreateRide(...): %v", err)
+	}
+	if _, err := c.CreateRide(ctx, Ride{ID: "flume", Name: "flume", Type: "logflume", Capacity: 4}); err != nil {
+		t.Fatalf("CreateRide(...): %v", err)
+	}
+	if coaster.ControlToken == "" {
+		t.Fatalf("CreateRide(...): want a generated control token")
+	}
+	if got := c.RideEndpoint("coaster"); got != srv.URL+"/rides/coaster" {
+		t.Errorf("RideEndpoint(...): want %s/rides/coaster, got %s", srv.URL, got)
+	}
+
+	rc := NewClient(srv.URL, WithBearerToken(coaster.ControlToken))
+	if _, err := rc.GetRide(ctx, "coaster"); err != nil {
+		t.Errorf("GetRide(...): want the control token to access its ride, got %v", err)
+	}
+	if _, err := rc.SetQueueLength(ctx, "coaster", 10); err != nil {
+		t.Errorf("SetQueueLength(...): want the control token to access its ride, got %v", err)
+	}
+	if _, err := rc.GetRide(ctx, "flume"); err == nil {
+		t.Errorf("GetRide(...): want error accessing another ride with a control token")
+	}
+	if _, err := rc.ListRides(ctx); err == nil {
+		t.Errorf("ListRides(...): want error listing rides with a control token")
+	}
+
+	o, err := c.CreateOperator(ctx, Operator{ID: "alice", Name: "alice", RideID: "coaster", Frequency: 10})
+	if err != nil {
+		t.Fatalf("CreateOperator(...): %v", err)
+	}
+	if o.Console == nil || o.Console.Username != "alice" || o.Console.Token == "" {
+		t.Fatalf("CreateOperator(...).Console: want generated credentials, got %+v", o.Console)
+	}
+	o.Frequency = 12
+	o.Console = nil
+	if _, err := c.UpdateOperator(ctx, *o); err != nil {
+		t.Fatalf("UpdateOperator(...): %v", err)
+	}
+
+	got, err := c.GetOperator(ctx, "alice")
+	if err != nil {
+		t.Fatalf("GetOperator(...): %v", err)
+	}
+	oc := NewClient(srv.URL, WithBearerToken(got.Console.Token))
+	if _, err := oc.GetOperator(ctx, "alice"); err != nil {
+		t.Errorf("GetOperator(...): want the console token to survive an update and read its operator, got %v", err)
+	}
+	if _, err := oc.UpdateOperator(ctx, *got); err == nil {
+		t.Errorf("UpdateOperator(...): want error updating an operator with a console token")
+	}
+}
+
+func TestConsoleTokenRotation(t *testing.T) {
+	srv := httptest.NewServer(NewServer(WithToken("s3cr3t")))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewClient(srv.URL, WithBearerToken("s3cr3t"))
+
+	for _, id := range []string{"alice", "bob"} {
+		if _, err := c.CreateOperator(ctx, Operator{ID: id, Name: id, Frequency: 10}); err != nil {
+			t.Fatalf("CreateOperator(...): %v", err)
+		}
+	}
+	alice, err := c.GetOperator(ctx, "alice")
+	if err != nil {
+		t.Fatalf("GetOperator(...): %v", err)
+	}
+	bob, err := c.GetOperator(ctx, "bob")
+	if err != nil {
+		t.Fatalf("GetOperator(...): %v", err)
+	}
+
+	overlapped, err := c.RotateConsoleToken(ctx, "alice", time.Hour)
+	if err != nil {
+		t.Fatalf("RotateConsoleToken(...): %v", err)
+	}
+	if overlapped.Console.Token == alice.Console.Token || !overlapped.Console.IssuedAt.After(alice.Console.IssuedAt) {
+		t.Fatalf("RotateConsoleToken(...): want a newly issued token, got %+v", overlapped.Console)
+	}
+	for _, token := range []string{alice.Console.Token, overlapped.Console.Token} {
+		if _, err := NewClient(srv.URL, WithBearerToken(token)).GetOperator(ctx, "alice"); err != nil {
+			t.Errorf("GetOperator(...): want the current and previous tokens to be valid during the overlap, got %v", err)
+		}
+	}
+
+	if _, err := c.RotateConsoleToken(ctx, "bob", 0); err != nil {
+		t.Fatalf("RotateConsoleToken(...): %v", err)
+	}
+	if _, err := NewClient(srv.URL, WithBearerToken(bob.Console.Token)).GetOperator(ctx, "bob"); err == nil {
+		t.Errorf("GetOperator(...): want error using a token rotated without overlap")
+	}
+}
@@ -0,0 +1,194 @@
+// Package park implements an in-memory ride control system and a client for
+// it. The control system is served over a small HTTP API so that the provider
+// has a real external system to create, observe, update and delete parks, rides
+// and operator assignments in.
+package park
+
+import (
+	"crypto/subtle"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// A Park as it is known to the control system.
+type Park struct {
+	// ID uniquely identifies the park in the control system.
+	ID string `json:"id"`
+
+	// Name is a human friendly name for the park.
+	Name string `json:"name"`
+
+	// TimeZone of the park, as an IANA time zone name.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// A Ride as it is known to the control system.
+type Ride struct {
+	// ID uniquely identifies the ride in the control system.
+	ID string `json:"id"`
+
+	// Name is a human friendly name for the ride.
+	Name string `json:"name"`
+
+	// Type of ride, e.g. rollercoaster.
+	Type string `json:"type"`
+
+	// Capacity is the riders per trip supported on this ride.
+	Capacity int `json:"capacity"`
+
+	// QueueLength is the number of guests queuing for this ride. Guests, not
+	// clients, decide how long the queue is, so it is ignored when a ride is
+	// updated. Use SetQueueLength to simulate guests joining or leaving.
+	QueueLength int `json:"queueLength,omitempty"`
+
+	// LastFault is the most recent fault of this ride. The ride is broken
+	// down until the fault is cleared. Faults are ignored when a ride is
+	// updated, use InjectFault and ClearFault to break down and repair it.
+	LastFault *Fault `json:"lastFault,omitempty"`
+
+	// Faults is the number of faults this ride has had.
+	Faults int `json:"faults,omitempty"`
+
+	// Downtime is how long this ride has been broken down by faults that
+	// have been cleared, in nanoseconds when encoded.
+	Downtime time.Duration `json:"downtime,omitempty"`
+
+	// ControlToken authorizes access to this ride, and only this ride, in the
+	// control system. It is generated when the ride is created and ignored
+	// when it is updated.
+	ControlToken string `json:"controlToken,omitempty"`
+}
+
+// A FaultKind is a kind of ride fault.
+type FaultKind string
+
+// Kinds of ride fault.
+const (
+	// FaultBreakdown is a mechanical breakdown of the ride.
+	FaultBreakdown FaultKind = "Breakdown"
+
+	// FaultEmergencyStop is an emergency stop of the ride, triggered by an
+	// operator or a guest.
+	FaultEmergencyStop FaultKind = "EmergencyStop"
+
+	// FaultSensor is a fault of one of the ride's safety sensors.
+	FaultSensor FaultKind = "SensorFault"
+)
+
+// FaultKinds are the kinds of fault a ride can have.
+var FaultKinds = []FaultKind{FaultBreakdown, FaultEmergencyStop, FaultSensor}
+
+// A Fault of a ride.
+type Fault struct {
+	// Kind of fault.
+	Kind FaultKind `json:"kind"`
+
+	// Message describing the fault.
+	Message string `json:"message,omitempty"`
+
+	// At is when the fault occurred.
+	At time.Time `json:"at"`
+
+	// ClearedAt is when the fault was cleared, if it has been.
+	ClearedAt *time.Time `json:"clearedAt,omitempty"`
+}
+
+// Active returns true if the fault has not been cleared.
+func (f *Fault) Active() bool {
+	return f != nil && f.ClearedAt == nil
+}
+
+// A Queue of guests waiting for a ride.
+type Queue struct {
+	// Length is the number of guests in the queue.
+	Length int `json:"length"`
+}
+
+// An Operator as it is known to the control system.
+type Operator struct {
+	// ID uniquely identifies the operator in the control system.
+	ID string `json:"id"`
+
+	// Name is a human friendly name for the operator.
+	Name string `json:"name"`
+
+	// RideID is the ride this operator is assigned to, if any.
+	RideID string `json:"rideID,omitempty"`
+
+	// Frequency is how often this operator operates the ride per hour.
+	Frequency int `json:"frequency"`
+
+	// Console is the credentials this operator uses to sign in to the ride
+	// console. They are generated when the operator is created and ignored
+	// when it is updated.
+	Console *ConsoleCredentials `json:"console,omitempty"`
+}
+
+// ConsoleCredentials authorize an operator to read their own assignment in the
+// control system.
+type ConsoleCredentials struct {
+	// Username the operator signs in to the console with.
+	Username string `json:"username"`
+
+	// Token the operator signs in to the console with.
+	Token string `json:"token"`
+
+	// IssuedAt is when the token was issued.
+	IssuedAt time.Time `json:"issuedAt"`
+
+	// PreviousToken is the token this one replaced. It remains valid until
+	// PreviousTokenExpiresAt so that the operator has time to switch tokens.
+	PreviousToken string `json:"previousToken,omitempty"`
+
+	// PreviousTokenExpiresAt is when PreviousToken stops being valid.
+	PreviousTokenExpiresAt *time.Time `json:"previousTokenExpiresAt,omitempty"`
+}
+
+// valid returns true if the supplied token is a console token that is valid at
+// the supplied time.
+func (c *ConsoleCredentials) valid(token string, now time.Time) bool {
+	switch {
+	case c == nil || token == "":
+		return false
+	case equalTokens(token, c.Token):
+		return true
+	case equalTokens(token, c.PreviousToken):
+		return c.PreviousTokenExpiresAt != nil && now.Before(*c.PreviousTokenExpiresAt)
+	}
+	return false
+}
+
+// equalTokens returns true if the supplied tokens are equal. It takes the same
+// time whatever the tokens have in common, so that a client can't guess a
+// token one character at a time.
+func equalTokens(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// A Rotation of an operator's console token.
+type Rotation struct {
+	// Overlap is how long the previous token remains valid, in nanoseconds
+	// when encoded. It is invalidated immediately when zero.
+	Overlap time.Duration `json:"overlap,omitempty"`
+}
+
+var (
+	// ErrNotFound is returned when a park, ride or operator does not exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrConflict is returned when a park, ride or operator already exists.
+	ErrConflict = errors.New("already exists")
+)
+
+// IsNotFound returns true if the supplied error indicates a park, ride or
+// operator does not exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsConflict returns true if the supplied error indicates a park, ride or
+// operator already exists.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
@@ -0,0 +1,576 @@
+package park
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	mathrand "math/rand/v2"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+)
+
+// Server is an in-memory ride control system. It implements http.Handler so
+// it can be served by the park command or embedded in tests using
+// httptest.NewServer.
+type Server struct {
+	mu        sync.RWMutex
+	parks     map[string]Park
+	rides     map[string]Ride
+	operators map[string]Operator
+
+	token string
+	mux   *http.ServeMux
+
+	// mtbf is the mean time between injected faults of each ride. Faults are
+	// only injected when it is positive.
+	mtbf time.Duration
+	rng  *mathrand.Rand
+
+	// nextFault is when each ride that is not broken down will next have an
+	// injected fault.
+	nextFault map[string]time.Time
+}
+
+// A ServerOption configures a Server.
+type ServerOption func(*Server)
+
+// WithToken requires clients to present the supplied bearer token. Any client
+// is accepted by default.
+func WithToken(token string) ServerOption {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// WithFaultInjection randomly breaks down each ride, on average once per the
+// supplied mean time between failures. Faults are drawn from a random source
+// seeded with the supplied seed, so the same seed and sequence of requests
+// injects the same faults. Injected faults last until they are cleared.
+func WithFaultInjection(mtbf time.Duration, seed uint64) ServerOption {
+	return func(s *Server) {
+		s.mtbf = mtbf
+		s.rng = mathrand.New(mathrand.NewPCG(seed, seed))
+	}
+}
+
+// NewServer returns an empty ride control system.
+func NewServer(opts ...ServerOption) *Server {
+	s := &Server{
+		parks:     map[string]Park{},
+		rides:     map[string]Ride{},
+		operators: map[string]Operator{},
+		mux:       http.NewServeMux(),
+		nextFault: map[string]time.Time{},
+	}
+	for _, o := range opts {
+		o(s)
+	}
+
+	s.mux.HandleFunc("GET /parks", s.listParks)
+	s.mux.HandleFunc("POST /parks", s.createPark)
+	s.mux.HandleFunc("GET /parks/{id}", s.getPark)
+	s.mux.HandleFunc("PUT /parks/{id}", s.updatePark)
+	s.mux.HandleFunc("DELETE /parks/{id}", s.deletePark)
+
+	s.mux.HandleFunc("GET /rides", s.listRides)
+	s.mux.HandleFunc("POST /rides", s.createRide)
+	s.mux.HandleFunc("GET /rides/{id}", s.getRide)
+	s.mux.HandleFunc("PUT /rides/{id}", s.updateRide)
+	s.mux.HandleFunc("DELETE /rides/{id}", s.deleteRide)
+	s.mux.HandleFunc("PUT /rides/{id}/queue", s.setQueue)
+	s.mux.HandleFunc("POST /rides/{id}/faults", s.injectFault)
+	s.mux.HandleFunc("DELETE /rides/{id}/faults", s.clearFault)
+
+	s.mux.HandleFunc("GET /operators", s.listOperators)
+	s.mux.HandleFunc("POST /operators", s.createOperator)
+	s.mux.HandleFunc("GET /operators/{id}", s.getOperator)
+	s.mux.HandleFunc("PUT /operators/{id}", s.updateOperator)
+	s.mux.HandleFunc("DELETE /operators/{id}", s.deleteOperator)
+	s.mux.HandleFunc("POST /operators/{id}/console/rotate", s.rotateConsole)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, errors.New("invalid or missing bearer token"))
+		return
+	}
+	s.injectFaults(time.Now())
+	s.mux.ServeHTTP(w, r)
+}
+
+// authorized returns true if the supplied request presents the server's token,
+// the control token of the ride it is for, or the console token of the
+// operator it reads.
+func (s *Server) authorized(r *http.Request) bool {
+	got := r.Header.Get("Authorization")
+	if s.token == "" || equalTokens(got, "Bearer "+s.token) {
+		return true
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(parts) >= 2 && parts[0] == "rides":
+		t := s.rides[parts[1]].ControlToken
+		return t != "" && equalTokens(got, "Bearer "+t)
+	case len(parts) == 2 && parts[0] == "operators" && r.Method == http.MethodGet:
+		return s.operators[parts[1]].Console.valid(strings.TrimPrefix(got, "Bearer "), time.Now())
+	}
+	return false
+}
+
+func (s *Server) listParks(w http.ResponseWriter, _ *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	parks := make([]Park, 0, len(s.parks))
+	for _, p := range s.parks {
+		parks = append(parks, p)
+	}
+	sort.Slice(parks, func(i, j int) bool { return parks[i].ID < parks[j].ID })
+	writeJSON(w, http.StatusOK, parks)
+}
+
+func (s *Server) createPark(w http.ResponseWriter, r *http.Request) {
+	p := Park{}
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		writeError(w, http.StatusBadRequest, errors.Wrap(err, "cannot decode park"))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p.ID == "" {
+		p.ID = newID("park")
+	}
+	if _, ok := s.parks[p.ID]; ok {
+		writeError(w, http.StatusConflict, errors.Errorf("park %q already exists", p.ID))
+		return
+	}
+	s.parks[p.ID] = p
+	writeJSON(w, http.StatusCreated, p)
+}
+
+func (s *Server) getPark(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.parks[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.Errorf("park %q not found", r.PathValue("id")))
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) updatePark(w http.ResponseWriter, r *http.Request) {
+	p := Park{}
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		writeError(w, http.StatusBadRequest, errors.Wrap(err, "cannot decode park"))
+		return
+	}
+	p.ID = r.PathValue("id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.parks[p.ID]; !ok {
+		writeError(w, http.StatusNotFound, errors.Errorf("park %q not found", p.ID))
+		return
+	}
+	s.parks[p.ID] = p
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) deletePark(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.parks[id]; !ok {
+		writeError(w, http.StatusNotFound, errors.Errorf("park %q not found", id))
+		return
+	}
+	delete(s.parks, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listRides(w http.ResponseWriter, _ *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rides := make([]Ride, 0, len(s.rides))
+	for _, r := range s.rides {
+		rides = append(rides, r)
+	}
+	sort.Slice(rides, func(i, j int) bool { return rides[i].ID < rides[j].ID })
+	writeJSON(w, http.StatusOK, rides)
+}
+
+func (s *Server) createRide(w http.ResponseWriter, r *http.Request) {
+	ride := Ride{}
+	if err := json.NewDecoder(r.Body).Decode(&ride); err != nil {
+		writeError(w, http.StatusBadRequest, errors.Wrap(err, "cannot decode ride"))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ride.ID == "" {
+		ride.ID = newID("ride")
+	}
+	if _, ok := s.rides[ride.ID]; ok {
+		writeError(w, http.StatusConflict, errors.Errorf("ride %q already exists", ride.ID))
+		return
+	}
+	ride.LastFault, ride.Faults, ride.Downtime = nil, 0, 0
+	ride.ControlToken = newToken()
+	s.rides[ride.ID] = ride
+	s.scheduleFault(ride.ID, time.Now())
+	writeJSON(w, http.StatusCreated, ride)
+}
+
+func (s *Server) getRide(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ride, ok := s.rides[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.Errorf("ride %q not found", r.PathValue("id")))
+		return
+	}
+	writeJSON(w, http.StatusOK, ride)
+}
+
+func (s *Server) updateRide(w http.ResponseWriter, r *http.Request) {
+	ride := Ride{}
+	if err := json.NewDecoder(r.Body).Decode(&ride); err != nil {
+		writeError(w, http.StatusBadRequest, errors.Wrap(err, "cannot decode ride"))
+		return
+	}
+	ride.ID = r.PathValue("id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.rides[ride.ID]
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.Errorf("ride %q not found", ride.ID))
+		return
+	}
+	ride.QueueLength = existing.QueueLength
+	ride.LastFault, ride.Faults, ride.Downtime = existing.LastFault, existing.Faults, existing.Downtime
+	ride.ControlToken = existing.ControlToken
+	s.rides[ride.ID] = ride
+	writeJSON(w, http.StatusOK, ride)
+}
+
+func (s *Server) setQueue(w http.ResponseWriter, r *http.Request) {
+	q := Queue{}
+	if err := json.NewDecoder(r.Body).Decode(&q); err != nil {
+		writeError(w, http.StatusBadRequest, errors.Wrap(err, "cannot decode queue"))
+		return
+	}
+	if q.Length < 0 {
+		writeError(w, http.StatusBadRequest, errors.Errorf("queue length %d is negative", q.Length))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	ride, ok := s.rides[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.Errorf("ride %q not found", id))
+		return
+	}
+	ride.QueueLength = q.Length
+	s.rides[id] = ride
+	writeJSON(w, http.StatusOK, ride)
+}
+
+func (s *Server) injectFault(w http.ResponseWriter, r *http.Request) {
+	f := Fault{}
+	if err := json.NewDecoder(r.Body).Decode(&f); err != nil {
+		writeError(w, http.StatusBadRequest, errors.Wrap(err, "cannot decode fault"))
+		return
+	}
+	if !validFault(f.Kind) {
+		writeError(w, http.StatusBadRequest, errors.Errorf("unknown fault kind %q", f.Kind))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	ride, ok := s.rides[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.Errorf("ride %q not found", id))
+		return
+	}
+	ride = breakDown(ride, f.Kind, f.Message, time.Now())
+	s.rides[id] = ride
+	delete(s.nextFault, id)
+	writeJSON(w, http.StatusOK, ride)
+}
+
+func (s *Server) clearFault(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	ride, ok := s.rides[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.Errorf("ride %q not found", id))
+		return
+	}
+	if ride.LastFault.Active() {
+		now := time.Now()
+		ride = repair(ride, now)
+		s.rides[id] = ride
+		s.scheduleFault(id, now)
+	}
+	writeJSON(w, http.StatusOK, ride)
+}
+
+func (s *Server) deleteRide(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.rides[id]; !ok {
+		writeError(w, http.StatusNotFound, errors.Errorf("ride %q not found", id))
+		return
+	}
+	delete(s.rides, id)
+	delete(s.nextFault, id)
+
+	// Operators of a deleted ride are no longer assigned to anything.
+	for oid, o := range s.operators {
+		if o.RideID == id {
+			o.RideID = ""
+			s.operators[oid] = o
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listOperators(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rideID := r.URL.Query().Get("ride")
+	operators := make([]Operator, 0, len(s.operators))
+	for _, o := range s.operators {
+		if rideID != "" && o.RideID != rideID {
+			continue
+		}
+		operators = append(operators, o)
+	}
+	sort.Slice(operators, func(i, j int) bool { return operators[i].ID < operators[j].ID })
+	writeJSON(w, http.StatusOK, operators)
+}
+
+func (s *Server) createOperator(w http.ResponseWriter, r *http.Request) {
+	o := Operator{}
+	if err := json.NewDecoder(r.Body).Decode(&o); err != nil {
+		writeError(w, http.StatusBadRequest, errors.Wrap(err, "cannot decode operator"))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if o.ID == "" {
+		o.ID = newID("operator")
+	}
+	if _, ok := s.operators[o.ID]; ok {
+		writeError(w, http.StatusConflict, errors.Errorf("operator %q already exists", o.ID))
+		return
+	}
+	o.Console = &ConsoleCredentials{Username: o.ID, Token: newToken(), IssuedAt: time.Now()}
+	s.operators[o.ID] = o
+	writeJSON(w, http.StatusCreated, o)
+}
+
+func (s *Server) getOperator(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	o, ok := s.operators[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.Errorf("operator %q not found", r.PathValue("id")))
+		return
+	}
+	writeJSON(w, http.StatusOK, o)
+}
+
+func (s *Server) updateOperator(w http.ResponseWriter, r *http.Request) {
+	o := Operator{}
+	if err := json.NewDecoder(r.Body).Decode(&o); err != nil {
+		writeError(w, http.StatusBadRequest, errors.Wrap(err, "cannot decode operator"))
+		return
+	}
+	o.ID = r.PathValue("id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.operators[o.ID]
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.Errorf("operator %q not found", o.ID))
+		return
+	}
+	o.Console = existing.Console
+	s.operators[o.ID] = o
+	writeJSON(w, http.StatusOK, o)
+}
+
+func (s *Server) rotateConsole(w http.ResponseWriter, r *http.Request) {
+	rot := Rotation{}
+	if err := json.NewDecoder(r.Body).Decode(&rot); err != nil {
+		writeError(w, http.StatusBadRequest, errors.Wrap(err, "cannot decode rotation"))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	o, ok := s.operators[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.Errorf("operator %q not found", id))
+		return
+	}
+
+	now := time.Now()
+	c := &ConsoleCredentials{Username: o.ID, Token: newToken(), IssuedAt: now}
+	if o.Console != nil && rot.Overlap > 0 {
+		expires := now.Add(rot.Overlap)
+		c.PreviousToken, c.PreviousTokenExpiresAt = o.Console.Token, &expires
+	}
+	o.Console = c
+	s.operators[id] = o
+	writeJSON(w, http.StatusOK, o)
+}
+
+func (s *Server) deleteOperator(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.operators[id]; !ok {
+		writeError(w, http.StatusNotFound, errors.Errorf("operator %q not found", id))
+		return
+	}
+	delete(s.operators, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// scheduleFault schedules the next injected fault of the ride with the
+// supplied ID, if faults are injected. Time between failures is exponentially
+// distributed, so faults are as likely to occur at any moment.
+func (s *Server) scheduleFault(id string, now time.Time) {
+	if s.mtbf <= 0 {
+		return
+	}
+	s.nextFault[id] = now.Add(time.Duration(s.rng.ExpFloat64() * float64(s.mtbf)))
+}
+
+// injectFaults breaks down every ride whose next injected fault is due at the
+// supplied time.
+func (s *Server) injectFaults(now time.Time) {
+	if s.mtbf <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Inject in a stable order so that a seed always injects the same faults.
+	due := make([]string, 0, len(s.nextFault))
+	for id, at := range s.nextFault {
+		if !at.After(now) {
+			due = append(due, id)
+		}
+	}
+	sort.Strings(due)
+	for _, id := range due {
+		kind := FaultKinds[s.rng.IntN(len(FaultKinds))]
+		s.rides[id] = breakDown(s.rides[id], kind, "injected fault", s.nextFault[id])
+		delete(s.nextFault, id)
+	}
+}
+
+// validFault returns true if the supplied kind is a known kind of fault.
+func validFault(kind FaultKind) bool {
+	for _, k := range FaultKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// breakDown returns the supplied ride broken down by a fault of the supplied
+// kind at the supplied time. Any fault the ride already has is cleared first.
+func breakDown(r Ride, kind FaultKind, message string, at time.Time) Ride {
+	if r.LastFault.Active() {
+		r = repair(r, at)
+	}
+	r.LastFault = &Fault{Kind: kind, Message: message, At: at}
+	r.Faults++
+	return r
+}
+
+// repair returns the supplied ride with its fault cleared at the supplied
+// time, adding the time it was broken down to its downtime.
+func repair(r Ride, at time.Time) Ride {
+	f := *r.LastFault
+	f.ClearedAt = &at
+	r.LastFault = &f
+	r.Downtime += at.Sub(f.At)
+	return r
+}
+
+// newID returns a random identifier with the supplied prefix.
+func newID(prefix string) string {
+	return prefix + "-" + utilrand.String(8)
+}
+
+// newToken returns a random token that is hard to guess.
+func newToken() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b) // Never returns an error.
+	return hex.EncodeToString(b)
+}
+
+// errorResponse is the body returned by the control system on error.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,80 @@
+// Package config reconciles ProviderConfigs, and connects the handlers to the
+// park control system a ProviderConfig configures.
+package config
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/providerconfig"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/pkg/park"
+)
+
+// Setup adds controllers that reconcile cluster-scoped and namespaced
+// ProviderConfigs by accounting for their current usage by managed resources.
+// A ProviderConfig that is in use cannot be deleted.
+func Setup(mgr ctrl.Manager, log logging.Logger) error {
+	name := providerconfig.ControllerName(v1alpha1.ProviderConfigGroupKind)
+
+	of := resource.ProviderConfigKinds{
+		Config:    v1alpha1.ProviderConfigGroupVersionKind,
+		UsageList: v1alpha1.ProviderConfigUsageListGroupVersionKind,
+	}
+
+	r := providerconfig.NewReconciler(mgr, of,
+		providerconfig.WithLogger(log.WithValues("controller", name)),
+		providerconfig.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	if err := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.ProviderConfig{}).
+		Watches(&v1alpha1.ProviderConfigUsage{}, &resource.EnqueueRequestForProviderConfig{}).
+		Complete(r); err != nil {
+		return err
+	}
+
+	return setupNamespaced(mgr, log)
+}
+
+// Connect records that the supplied managed resource uses its ProviderConfig,
+// and returns a client for the park control system that ProviderConfig
+// configures. A namespaced managed resource uses a namespaced ProviderConfig in
+// its own namespace.
+func Connect(ctx context.Context, kube client.Client, mg resource.Managed) (*park.Client, error) {
+	ref := mg.GetProviderConfigReference()
+	if ref == nil {
+		return nil, errors.New("managed resource does not reference a ProviderConfig")
+	}
+
+	if mg.GetNamespace() != "" {
+		return connectNamespaced(ctx, kube, mg)
+	}
+
+	t := resource.NewProviderConfigUsageTracker(kube, &v1alpha1.ProviderConfigUsage{})
+	if err := t.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, "cannot track ProviderConfig usage")
+	}
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		return nil, errors.Wrap(err, "cannot get ProviderConfig")
+	}
+
+	cd := pc.Spec.Credentials
+	token, err := resource.CommonCredentialExtractor(ctx, cd.Source, kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get credentials")
+	}
+
+	return park.NewClient(pc.Spec.Endpoint, park.WithBearerToken(strings.TrimSpace(string(token)))), nil
+}
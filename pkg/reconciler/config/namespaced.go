@@ -0,0 +1,174 @@
+package config
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/providerconfig"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	namespaced "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/pkg/park"
+)
+
+// FinalizerNamespacedInUse keeps a namespaced ProviderConfig from being
+// deleted while namespaced managed resources use it.
+const FinalizerNamespacedInUse = "in-use.themepark.m.n3wscott.com"
+
+// setupNamespaced adds a controller that accounts for the usage of namespaced
+// ProviderConfigs. The providerconfig.Reconciler can't, because it counts the
+// usages of ProviderConfigs of the same name in every namespace.
+func setupNamespaced(mgr ctrl.Manager, log logging.Logger) error {
+	name := providerconfig.ControllerName(namespaced.ProviderConfigGroupKind)
+
+	r := &namespacedUsageReconciler{
+		kube:      mgr.GetClient(),
+		finalizer: resource.NewAPIFinalizer(mgr.GetClient(), FinalizerNamespacedInUse),
+		log:       log.WithValues("controller", name),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&namespaced.ProviderConfig{}).
+		Watches(&namespaced.ProviderConfigUsage{}, handler.EnqueueRequestsFromMapFunc(providerConfigOf)).
+		Complete(r)
+}
+
+// providerConfigOf returns a request to reconcile the namespaced
+// ProviderConfig the supplied namespaced ProviderConfigUsage is a usage of.
+func providerConfigOf(_ context.Context, o client.Object) []reconcile.Request {
+	pcu, ok := o.(*namespaced.ProviderConfigUsage)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: pcu.GetNamespace(),
+		Name:      pcu.GetProviderConfigReference().Name,
+	}}}
+}
+
+// namespacedUsageReconciler accounts for the usage of namespaced
+// ProviderConfigs.
+type namespacedUsageReconciler struct {
+	kube      client.Client
+	finalizer resource.Finalizer
+	log       logging.Logger
+}
+
+// Reconcile records how many namespaced managed resources use a namespaced
+// ProviderConfig, and keeps it from being deleted while any do.
+func (r *namespacedUsageReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	r.log.Debug("Reconciling", "request", req)
+
+	pc := &namespaced.ProviderConfig{}
+	if err := r.kube.Get(ctx, req.NamespacedName, pc); err != nil {
+		return reconcile.Result{}, errors.Wrap(client.IgnoreNotFound(err), "cannot get ProviderConfig")
+	}
+
+	l := &namespaced.ProviderConfigUsageList{}
+	if err := r.kube.List(ctx, l, client.InNamespace(pc.GetNamespace()), client.MatchingLabels{xpv1.LabelKeyProviderName: pc.GetName()}); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "cannot list ProviderConfigUsages")
+	}
+
+	if users := int64(len(l.Items)); pc.GetUsers() != users {
+		pc.SetUsers(users)
+		if err := r.kube.Status().Update(ctx, pc); err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "cannot update ProviderConfig status")
+		}
+	}
+
+	if len(l.Items) == 0 {
+		return reconcile.Result{}, errors.Wrap(r.finalizer.RemoveFinalizer(ctx, pc), "cannot remove ProviderConfig finalizer")
+	}
+	// Finalizers can't be added to a ProviderConfig that is being deleted.
+	if meta.WasDeleted(pc) {
+		return reconcile.Result{}, nil
+	}
+	return reconcile.Result{}, errors.Wrap(r.finalizer.AddFinalizer(ctx, pc), "cannot add ProviderConfig finalizer")
+}
+
+// connectNamespaced records that the supplied namespaced managed resource uses
+// its ProviderConfig, and returns a client for the park control system that
+// ProviderConfig configures. Only a namespaced ProviderConfig in the namespace
+// of the managed resource can be used, so a team can't use the credentials of
+// another.
+func connectNamespaced(ctx context.Context, kube client.Client, mg resource.Managed) (*park.Client, error) {
+	if err := trackNamespaced(ctx, kube, mg); err != nil {
+		return nil, errors.Wrap(err, "cannot track ProviderConfig usage")
+	}
+
+	ref := mg.GetProviderConfigReference()
+	pc := &namespaced.ProviderConfig{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: mg.GetNamespace(), Name: ref.Name}, pc); err != nil {
+		return nil, errors.Wrapf(err, "cannot get ProviderConfig %q in namespace %q", ref.Name, mg.GetNamespace())
+	}
+
+	token, err := namespacedCredentials(ctx, kube, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get credentials")
+	}
+
+	return park.NewClient(pc.Spec.Endpoint, park.WithBearerToken(strings.TrimSpace(string(token)))), nil
+}
+
+// namespacedCredentials returns the credentials of the supplied namespaced
+// ProviderConfig, which can only be read from a Secret in its namespace.
+func namespacedCredentials(ctx context.Context, kube client.Reader, pc *namespaced.ProviderConfig) ([]byte, error) {
+	cd := pc.Spec.Credentials
+	switch cd.Source {
+	case xpv1.CredentialsSourceNone:
+		return nil, nil
+	case xpv1.CredentialsSourceSecret:
+		if cd.SecretRef == nil {
+			return nil, errors.New("no credentials Secret is referenced")
+		}
+		s := &corev1.Secret{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: pc.GetNamespace(), Name: cd.SecretRef.Name}, s); err != nil {
+			return nil, errors.Wrap(err, "cannot get credentials Secret")
+		}
+		return s.Data[cd.SecretRef.Key], nil
+	default:
+		return nil, errors.Errorf("credentials source %q is not supported by a namespaced ProviderConfig", cd.Source)
+	}
+}
+
+// trackNamespaced records that the supplied namespaced managed resource uses
+// its ProviderConfig. Like resource.ProviderConfigUsageTracker, but the
+// ProviderConfigUsage is created in the namespace of the managed resource.
+func trackNamespaced(ctx context.Context, kube client.Client, mg resource.Managed) error {
+	gvk := mg.GetObjectKind().GroupVersionKind()
+	ref := mg.GetProviderConfigReference()
+
+	pcu := &namespaced.ProviderConfigUsage{}
+	pcu.SetNamespace(mg.GetNamespace())
+	pcu.SetName(string(mg.GetUID()))
+	pcu.SetLabels(map[string]string{xpv1.LabelKeyProviderName: ref.Name})
+	pcu.SetOwnerReferences([]metav1.OwnerReference{meta.AsController(meta.TypedReferenceTo(mg, gvk))})
+	pcu.SetProviderConfigReference(xpv1.Reference{Name: ref.Name})
+	pcu.SetResourceReference(xpv1.TypedReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       mg.GetName(),
+	})
+
+	err := resource.NewAPIPatchingApplicator(kube).Apply(ctx, pcu,
+		resource.MustBeControllableBy(mg.GetUID()),
+		resource.AllowUpdateIf(func(current, _ runtime.Object) bool {
+			return current.(*namespaced.ProviderConfigUsage).GetProviderConfigReference() != pcu.GetProviderConfigReference() //nolint:forcetypeassert // Current is the same type as the supplied usage.
+		}),
+	)
+	return errors.Wrap(resource.Ignore(resource.IsNotAllowed, err), "cannot apply ProviderConfigUsage")
+}
@@ -0,0 +1,42 @@
+package park
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	namespaced "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+)
+
+// IndexKeyRidePark indexes Rides by the name of the Park they are in. The
+// Cache of a ConnectorWrapper must be indexed by it.
+const IndexKeyRidePark = "spec.forProvider.parkRef.name"
+
+// SetupIndexes adds the fields the Cache of a ConnectorWrapper must be indexed
+// by to the supplied indexer.
+func SetupIndexes(ctx context.Context, i client.FieldIndexer) error {
+	if err := i.IndexField(ctx, &v1alpha1.Ride{}, IndexKeyRidePark, func(o client.Object) []string {
+		return ridePark(o.(*v1alpha1.Ride).Spec.ForProvider.ParkRef) //nolint:forcetypeassert // Only called for Rides.
+	}); err != nil {
+		return errors.Wrap(err, "cannot index Rides by Park")
+	}
+	if err := i.IndexField(ctx, &namespaced.Ride{}, IndexKeyRidePark, func(o client.Object) []string {
+		return ridePark(o.(*namespaced.Ride).Spec.ForProvider.ParkRef) //nolint:forcetypeassert // Only called for Rides.
+	}); err != nil {
+		return errors.Wrap(err, "cannot index namespaced Rides by Park")
+	}
+	return nil
+}
+
+// ridePark returns the name of the supplied Park as an index value, if a Ride
+// is in one.
+func ridePark(ref *xpv1.Reference) []string {
+	if ref == nil {
+		return nil
+	}
+	return []string{ref.Name}
+}
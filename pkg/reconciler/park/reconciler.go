@@ -0,0 +1,284 @@
+package park
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	namespaced "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+	parkapi "github.com/n3wscott/theme-park-provider/pkg/park"
+	"github.com/n3wscott/theme-park-provider/pkg/reconciler/config"
+	"github.com/n3wscott/theme-park-provider/pkg/reconciler/policy"
+	"github.com/n3wscott/theme-park-provider/pkg/requeue"
+)
+
+// ConnectorWrapper wraps the connector for gRPC support.
+type ConnectorWrapper struct {
+	Log logging.Logger
+
+	// Client is used to resolve the ProviderConfig of a Park and track its
+	// usage.
+	Client client.Client
+
+	// Cache is used to look up the Rides in a Park, which are listed on every
+	// observation. It must be indexed by SetupIndexes.
+	Cache client.Reader
+
+	// Requeue is used to reconcile a Park when it opens or closes. Parks are
+	// only reconciled when polled if it is nil.
+	Requeue *requeue.Scheduler
+}
+
+// Connect implements the TypedExternalConnector interface.
+func (c *ConnectorWrapper) Connect(ctx context.Context, mg resource.Managed) (managed.TypedExternalClient[resource.Managed], error) {
+	log := c.Log
+	if log == nil {
+		log = logging.NewNopLogger()
+	}
+	conn := &connector{log: log, kube: c.Client, cache: c.Cache, requeue: c.Requeue}
+	return conn.Connect(ctx, mg)
+}
+
+// connector satisfies the resource.ExternalConnector interface.
+type connector struct {
+	log     logging.Logger
+	kube    client.Client
+	cache   client.Reader
+	requeue *requeue.Scheduler
+}
+
+// Connect to the supplied resource.Managed (presumed to be a Park) by using the Provider.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	c.log.Debug("Connecting to provider")
+
+	if _, ok := mg.(*v1alpha1.Park); !ok {
+		return nil, errors.New("managed resource is not a Park")
+	}
+
+	if c.kube == nil {
+		return nil, errors.New("no Kubernetes client configured for Park")
+	}
+	if c.cache == nil {
+		return nil, errors.New("no Kubernetes cache configured for Park")
+	}
+
+	pc, err := config.Connect(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{log: c.log, cache: c.cache, park: pc, requeue: c.requeue}, nil
+}
+
+// External satisfies the resource.ExternalClient interface.
+type external struct {
+	log     logging.Logger
+	cache   client.Reader
+	park    *parkapi.Client
+	requeue *requeue.Scheduler
+}
+
+// Observe the existing external resource, if any. The managed.Reconciler
+// calls Observe in order to determine whether an external resource needs to be
+// created, updated, or deleted.
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	gvk := mg.GetObjectKind().GroupVersionKind().String()
+	e.log.Debug("Observing", "type", gvk)
+
+	i, ok := mg.(*v1alpha1.Park)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New("managed resource is not a Park")
+	}
+
+	// The external name is the ID the park control system assigned to the
+	// park when it was created. Setting it before the Park is created adopts
+	// an existing park.
+	id := meta.GetExternalName(i)
+	if id == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	pp, err := e.park.GetPark(ctx, id)
+	if parkapi.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, "cannot get park from control system")
+	}
+
+	i.SetConditions(xpv1.Available())
+
+	want, err := e.desiredState(ctx, i)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	e.requeue.At(i, want.nextChange)
+
+	// Update applies the desired state along with the spec, but won't be
+	// called to do so when the management policies don't allow updates.
+	d := specDiff(i, pp)
+	if policy.Allows(i, xpv1.ManagementActionUpdate) {
+		d = append(d, want.diff(i)...)
+	} else {
+		e.applyState(ctx, i, want)
+	}
+	diff := strings.Join(d, "; ")
+
+	o := managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: diff == "",
+		Diff:             diff,
+	}
+
+	return o, nil
+}
+
+// Create a new external resource based on the specification of our managed
+// resource. managed.Reconciler only calls Create if Observe reported
+// that the external resource did not exist.
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	gvk := mg.GetObjectKind().GroupVersionKind().String()
+	e.log.Debug("Create", "type", gvk)
+
+	i, ok := mg.(*v1alpha1.Park)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New("managed resource is not a Park")
+	}
+	if err := policy.Check(i, xpv1.ManagementActionCreate); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, "cannot create park in control system")
+	}
+
+	pp, err := e.park.CreatePark(ctx, toPark(i))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, "cannot create park in control system")
+	}
+	meta.SetExternalName(i, pp.ID)
+
+	i.SetConditions(xpv1.Creating())
+
+	return managed.ExternalCreation{}, nil
+}
+
+// Update the existing external resource to match the specifications of our
+// managed resource. managed.Reconciler only calls Update if Observe
+// reported that the external resource was not up to date.
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	gvk := mg.GetObjectKind().GroupVersionKind().String()
+	e.log.Debug("Update", "type", gvk)
+
+	i, ok := mg.(*v1alpha1.Park)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New("managed resource is not a Park")
+	}
+	// Observe applies the desired state itself when the provider may not
+	// change the park in the control system.
+	if !policy.Allows(i, xpv1.ManagementActionUpdate) {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	pp := toPark(i)
+	pp.ID = meta.GetExternalName(i)
+	if _, err := e.park.UpdatePark(ctx, pp); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, "cannot update park in control system")
+	}
+
+	want, err := e.desiredState(ctx, i)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	e.applyState(ctx, i, want)
+
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete the external resource. managed.Reconciler only calls Delete
+// when a managed resource with the 'Delete' deletion policy (the default) has
+// been deleted.
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	gvk := mg.GetObjectKind().GroupVersionKind().String()
+	e.log.Debug("Delete", "type", gvk)
+
+	i, ok := mg.(*v1alpha1.Park)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New("managed resource is not a Park")
+	}
+	// Indicate that we're about to delete the instance.
+	i.SetConditions(xpv1.Deleting())
+
+	// A Park without an external name was never created in the control system,
+	// and one the provider may not delete is left in it.
+	id := meta.GetExternalName(i)
+	if id == "" || !policy.Allows(i, xpv1.ManagementActionDelete) {
+		return managed.ExternalDelete{}, nil
+	}
+	if err := e.park.DeletePark(ctx, id); err != nil && !parkapi.IsNotFound(err) {
+		return managed.ExternalDelete{}, errors.Wrap(err, "cannot delete park from control system")
+	}
+
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *external) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// applyState applies the supplied desired state to the supplied Park, and lets
+// the Rides in the Park know if it opened or closed.
+func (e *external) applyState(ctx context.Context, p *v1alpha1.Park, s parkState) {
+	// Rides in the Park start or stop operating when it opens or closes.
+	if p.Status.Open != s.open {
+		e.requeueRides(ctx, p)
+	}
+	s.apply(p)
+}
+
+// ridesIn returns the Rides whose spec.forProvider.parkRef points at the
+// supplied Park. Namespaced Rides in any namespace can be in a Park, and are
+// returned as cluster-scoped ones.
+func (e *external) ridesIn(ctx context.Context, p *v1alpha1.Park) ([]v1alpha1.Ride, error) {
+	in := client.MatchingFields{IndexKeyRidePark: p.GetName()}
+	rs := new(v1alpha1.RideList)
+	if err := e.cache.List(ctx, rs, in); err != nil {
+		return nil, errors.Wrap(err, "cannot list Rides")
+	}
+	nrs := new(namespaced.RideList)
+	if err := e.cache.List(ctx, nrs, in); err != nil {
+		return nil, errors.Wrap(err, "cannot list namespaced Rides")
+	}
+	for i := range nrs.Items {
+		rs.Items = append(rs.Items, *nrs.Items[i].ToCluster())
+	}
+	return rs.Items, nil
+}
+
+// requeueRides requests a reconcile of every Ride in the supplied Park.
+func (e *external) requeueRides(ctx context.Context, p *v1alpha1.Park) {
+	rs, err := e.ridesIn(ctx, p)
+	if err != nil {
+		e.log.Debug("Cannot requeue Rides in Park", "error", err)
+		return
+	}
+	now := time.Now()
+	for i := range rs {
+		e.requeue.At(rideObject(&rs[i]), now)
+	}
+}
+
+// rideObject returns the object to requeue for the supplied Ride, which is a
+// namespaced Ride if it has a namespace.
+func rideObject(r *v1alpha1.Ride) client.Object {
+	if r.GetNamespace() == "" {
+		return r
+	}
+	return &namespaced.Ride{ObjectMeta: r.ObjectMeta}
+}
@@ -0,0 +1,207 @@
+package park
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	namespaced "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/pkg/reconciler/ride"
+)
+
+var _ = Describe("Park handler", func() {
+	const (
+		parkName      = "test-park"
+		teamNamespace = "team-a"
+	)
+
+	var (
+		park *v1alpha1.Park
+		ext  managed.TypedExternalClient[resource.Managed]
+	)
+
+	BeforeEach(func() {
+		park = &v1alpha1.Park{
+			ObjectMeta: metav1.ObjectMeta{Name: parkName},
+			Spec: v1alpha1.ParkSpec{
+				ForProvider: v1alpha1.ParkParameters{
+					Name:     "Test Park",
+					TimeZone: "UTC",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, park)).To(Succeed())
+		// The gRPC server supplies fully typed objects, the typed client does
+		// not.
+		park.SetGroupVersionKind(v1alpha1.ParkGroupVersionKind)
+
+		c := &ConnectorWrapper{Client: k8sClient, Cache: k8sCache}
+		var err error
+		ext, err = c.Connect(ctx, park)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		deleteAll(&v1alpha1.Ride{}, &v1alpha1.RideList{}, "")
+		deleteAll(&namespaced.Ride{}, &namespaced.RideList{}, teamNamespace)
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, park))).To(Succeed())
+		parks, err := parkClient.ListParks(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		for _, pp := range parks {
+			Expect(parkClient.DeletePark(ctx, pp.ID)).To(Succeed())
+		}
+	})
+
+	It("should manage the park in the control system", func() {
+		By("observing a Park that does not exist in the control system")
+		obs, err := ext.Observe(ctx, park)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceExists).To(BeFalse())
+
+		By("creating the park")
+		_, err = ext.Create(ctx, park)
+		Expect(err).NotTo(HaveOccurred())
+		id := meta.GetExternalName(park)
+		Expect(id).NotTo(BeEmpty())
+
+		By("changing the park in the control system out-of-band")
+		pp, err := parkClient.GetPark(ctx, id)
+		Expect(err).NotTo(HaveOccurred())
+		pp.TimeZone = "America/New_York"
+		_, err = parkClient.UpdatePark(ctx, *pp)
+		Expect(err).NotTo(HaveOccurred())
+
+		obs, err = ext.Observe(ctx, park)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceExists).To(BeTrue())
+		Expect(obs.ResourceUpToDate).To(BeFalse())
+		Expect(obs.Diff).To(ContainSubstring(`timeZone: "America/New_York" -> "UTC"`))
+
+		By("updating the park")
+		_, err = ext.Update(ctx, park)
+		Expect(err).NotTo(HaveOccurred())
+		obs, err = ext.Observe(ctx, park)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceUpToDate).To(BeTrue())
+
+		By("deleting the park")
+		_, err = ext.Delete(ctx, park)
+		Expect(err).NotTo(HaveOccurred())
+		obs, err = ext.Observe(ctx, park)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceExists).To(BeFalse())
+	})
+
+	It("should report whether the park is open", func() {
+		_, err := ext.Create(ctx, park)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = ext.Update(ctx, park)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(park.Status.Open).To(BeTrue())
+
+		park.Spec.ForProvider.ClosureDates = []v1alpha1.ClosureDate{v1alpha1.ClosureDate(time.Now().UTC().Format(time.DateOnly))}
+		obs, err := ext.Observe(ctx, park)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.Diff).To(ContainSubstring("open: true -> false"))
+		_, err = ext.Update(ctx, park)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(park.Status.Open).To(BeFalse())
+	})
+
+	It("should aggregate the rides in the park", func() {
+		_, err := ext.Create(ctx, park)
+		Expect(err).NotTo(HaveOccurred())
+
+		newRide(parkName, "operating-ride", ride.Operating(), 96)
+		newRide(parkName, "short-staffed-ride", ride.ShortStaffed(), 0)
+		newRide(parkName, "uncertified-ride", ride.UncertifiedOperator("not certified"), 0)
+		newRide(parkName, "closed-ride", ride.ParkClosed("closed"), 0)
+		newRide("other-park", "other-ride", ride.Operating(), 48)
+
+		obs, err := ext.Observe(ctx, park)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceUpToDate).To(BeFalse())
+		Expect(obs.Diff).To(ContainSubstring("ridersPerHour: 0 -> 96"))
+
+		_, err = ext.Update(ctx, park)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(park.Status.OpenRides).To(Equal(1))
+		Expect(park.Status.RidersPerHour).To(Equal(96))
+		Expect(park.Status.StaffingGaps).To(HaveLen(2))
+		Expect(park.Status.StaffingGaps[0].Name).To(Equal("short-staffed-ride"))
+		Expect(park.Status.StaffingGaps[1].Name).To(Equal("uncertified-ride"))
+
+		obs, err = ext.Observe(ctx, park)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceUpToDate).To(BeTrue())
+	})
+
+	It("should aggregate the namespaced rides in the park", func() {
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: teamNamespace}}))).To(Succeed())
+		_, err := ext.Create(ctx, park)
+		Expect(err).NotTo(HaveOccurred())
+
+		newRide(parkName, "operating-ride", ride.Operating(), 96)
+		newNamespacedRide(teamNamespace, parkName, "team-ride", ride.Operating(), 48)
+		newNamespacedRide(teamNamespace, parkName, "short-staffed-ride", ride.ShortStaffed(), 0)
+
+		_, err = ext.Update(ctx, park)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(park.Status.OpenRides).To(Equal(2))
+		Expect(park.Status.RidersPerHour).To(Equal(144))
+		Expect(park.Status.StaffingGaps).To(HaveLen(1))
+		Expect(park.Status.StaffingGaps[0].APIVersion).To(Equal(namespaced.GroupVersion.String()))
+		Expect(park.Status.StaffingGaps[0].Name).To(Equal("short-staffed-ride"))
+	})
+})
+
+// newRide creates a Ride in the supplied Park that reports the supplied
+// operational condition and throughput.
+func newRide(park, name string, c xpv1.Condition, ridersPerHour int) {
+	r := &v1alpha1.Ride{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.RideSpec{
+			ForProvider: v1alpha1.RideParameters{
+				Type:     "rollercoaster",
+				Capacity: ptr.To(24),
+				ParkRef:  &xpv1.Reference{Name: park},
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, r)).To(Succeed())
+	r.SetConditions(c)
+	r.Status.RidersPerHour = ridersPerHour
+	updateStatus(r)
+}
+
+// newNamespacedRide creates a namespaced Ride in the supplied Park that reports
+// the supplied operational condition and throughput.
+func newNamespacedRide(namespace, park, name string, c xpv1.Condition, ridersPerHour int) {
+	r := &namespaced.Ride{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: namespaced.RideSpec{
+			ForProvider: namespaced.RideParameters{
+				Type:     "rollercoaster",
+				Capacity: ptr.To(24),
+				ParkRef:  &xpv1.Reference{Name: park},
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, r)).To(Succeed())
+	r.SetConditions(c)
+	r.Status.RidersPerHour = ridersPerHour
+	updateStatus(r)
+}
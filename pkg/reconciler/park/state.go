@@ -0,0 +1,142 @@
+package park
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	namespaced "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+	parkapi "github.com/n3wscott/theme-park-provider/pkg/park"
+	"github.com/n3wscott/theme-park-provider/pkg/reconciler/ride"
+	"github.com/n3wscott/theme-park-provider/pkg/schedule"
+)
+
+// parkState is what a Park should report in its status given its opening
+// hours and the Rides in it.
+type parkState struct {
+	open          bool
+	openRides     int
+	ridersPerHour int
+	staffingGaps  []xpv1.TypedReference
+
+	// nextChange is when the Park next opens or closes.
+	nextChange time.Time
+}
+
+// desiredState computes the state of the supplied Park.
+func (e *external) desiredState(ctx context.Context, p *v1alpha1.Park) (parkState, error) {
+	open, next, err := schedule.ParkOpen(p, time.Now())
+	if err != nil {
+		return parkState{}, err
+	}
+
+	rs, err := e.ridesIn(ctx, p)
+	if err != nil {
+		return parkState{}, err
+	}
+
+	s := parkState{open: open, nextChange: next, staffingGaps: make([]xpv1.TypedReference, 0, len(rs))}
+	for _, r := range rs {
+		switch r.GetCondition(ride.TypeOperational).Reason {
+		case ride.ReasonOperating:
+			s.openRides++
+			s.ridersPerHour += r.Status.RidersPerHour
+		case ride.ReasonShortStaffed, ride.ReasonPartiallyStaffed, ride.ReasonUncertifiedOperator:
+			s.staffingGaps = append(s.staffingGaps, rideRef(r))
+		}
+	}
+	return s, nil
+}
+
+// rideRef returns a reference to the supplied Ride. The typed client does not
+// populate TypeMeta, so the kind and version are constant for cluster-scoped
+// and namespaced Rides.
+func rideRef(r v1alpha1.Ride) xpv1.TypedReference {
+	gv := v1alpha1.GroupVersion
+	if r.GetNamespace() != "" {
+		gv = namespaced.GroupVersion
+	}
+	return xpv1.TypedReference{
+		APIVersion: gv.String(),
+		Kind:       v1alpha1.RideKind,
+		Name:       r.Name,
+		UID:        r.UID,
+	}
+}
+
+// apply writes the state to the status of the supplied Park.
+func (s parkState) apply(p *v1alpha1.Park) {
+	p.Status.Open = s.open
+	p.Status.OpenRides = s.openRides
+	p.Status.RidersPerHour = s.ridersPerHour
+	p.Status.StaffingGaps = s.staffingGaps
+}
+
+// diff returns a human-readable description of each way the status of the
+// supplied Park differs from the state.
+func (s parkState) diff(p *v1alpha1.Park) []string {
+	var d []string
+	if got, want := p.Status.Open, s.open; got != want {
+		d = append(d, fmt.Sprintf("open: %t -> %t", got, want))
+	}
+	if got, want := p.Status.OpenRides, s.openRides; got != want {
+		d = append(d, fmt.Sprintf("openRides: %d -> %d", got, want))
+	}
+	if got, want := p.Status.RidersPerHour, s.ridersPerHour; got != want {
+		d = append(d, fmt.Sprintf("ridersPerHour: %d -> %d", got, want))
+	}
+	if !sameRides(p.Status.StaffingGaps, s.staffingGaps) {
+		d = append(d, fmt.Sprintf("staffingGaps: [%s] -> [%s]", rideNames(p.Status.StaffingGaps), rideNames(s.staffingGaps)))
+	}
+	return d
+}
+
+// specDiff returns a human-readable description of each way the park in the
+// control system differs from the spec of the supplied Park.
+func specDiff(p *v1alpha1.Park, pp *parkapi.Park) []string {
+	var d []string
+	want := toPark(p)
+	if pp.Name != want.Name {
+		d = append(d, fmt.Sprintf("name: %q -> %q", pp.Name, want.Name))
+	}
+	if pp.TimeZone != want.TimeZone {
+		d = append(d, fmt.Sprintf("timeZone: %q -> %q", pp.TimeZone, want.TimeZone))
+	}
+	return d
+}
+
+// toPark returns the park the control system should have for the supplied
+// Park. The ID is left for the control system to assign.
+func toPark(p *v1alpha1.Park) parkapi.Park {
+	return parkapi.Park{
+		Name:     p.Spec.ForProvider.Name,
+		TimeZone: p.Spec.ForProvider.TimeZone,
+	}
+}
+
+// sameRides returns true if both lists reference the same Rides in the same
+// order.
+func sameRides(a, b []xpv1.TypedReference) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name || a[i].UID != b[i].UID {
+			return false
+		}
+	}
+	return true
+}
+
+// rideNames returns the names of the supplied Rides.
+func rideNames(refs []xpv1.TypedReference) string {
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, ref.Name)
+	}
+	return strings.Join(names, ", ")
+}
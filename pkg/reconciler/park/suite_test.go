@@ -0,0 +1,173 @@
+package park
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	namespaced "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+	parkapi "github.com/n3wscott/theme-park-provider/pkg/park"
+)
+
+// These tests use Ginkgo (BDD-style Go testing framework). Refer to
+// http://onsi.github.io/ginkgo/ to learn more about Ginkgo.
+
+var (
+	ctx       context.Context
+	cancel    context.CancelFunc
+	testEnv   *envtest.Environment
+	cfg       *rest.Config
+	k8sClient client.Client
+	k8sCache  cache.Cache
+
+	parkServer *httptest.Server
+	parkClient *parkapi.Client
+)
+
+const parkToken = "test-token"
+
+func TestPark(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "Park Reconciler Suite")
+}
+
+var _ = BeforeSuite(func() {
+	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
+
+	ctx, cancel = context.WithCancel(context.TODO())
+
+	var err error
+	err = v1alpha1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+	err = namespaced.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	By("bootstrapping test environment")
+	testEnv = &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "..", "config", "crd", "bases")},
+		ErrorIfCRDPathMissing: true,
+	}
+
+	// Retrieve the first found binary directory to allow running tests from IDEs
+	if getFirstFoundEnvTestBinaryDir() != "" {
+		testEnv.BinaryAssetsDirectory = getFirstFoundEnvTestBinaryDir()
+	}
+
+	// cfg is defined in this file globally.
+	cfg, err = testEnv.Start()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(cfg).NotTo(BeNil())
+
+	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(k8sClient).NotTo(BeNil())
+
+	By("starting an indexed cache")
+	k8sCache, err = cache.New(cfg, cache.Options{Scheme: scheme.Scheme})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(SetupIndexes(ctx, k8sCache)).To(Succeed())
+	go func() {
+		defer GinkgoRecover()
+		Expect(k8sCache.Start(ctx)).To(Succeed())
+	}()
+	Expect(k8sCache.WaitForCacheSync(ctx)).To(BeTrue())
+
+	By("starting the park control system")
+	parkServer = httptest.NewServer(parkapi.NewServer(parkapi.WithToken(parkToken)))
+	parkClient = parkapi.NewClient(parkServer.URL, parkapi.WithBearerToken(parkToken))
+
+	By("configuring the provider to use the park control system")
+	Expect(k8sClient.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "park-credentials"},
+		StringData: map[string]string{"token": parkToken},
+	})).To(Succeed())
+	Expect(k8sClient.Create(ctx, &v1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec: v1alpha1.ProviderConfigSpec{
+			Endpoint: parkServer.URL,
+			Credentials: v1alpha1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					SecretRef: &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{Namespace: "default", Name: "park-credentials"},
+						Key:             "token",
+					},
+				},
+			},
+		},
+	})).To(Succeed())
+})
+
+var _ = AfterSuite(func() {
+	By("tearing down the test environment")
+	parkServer.Close()
+	cancel()
+	err := testEnv.Stop()
+	Expect(err).NotTo(HaveOccurred())
+})
+
+// updateStatus updates the status of the supplied object and waits for the
+// cache to see it, so that a handler observing right after finds it.
+func updateStatus(o client.Object) {
+	GinkgoHelper()
+	Expect(k8sClient.Status().Update(ctx, o)).To(Succeed())
+	Eventually(func(g Gomega) {
+		got := o.DeepCopyObject().(client.Object) //nolint:forcetypeassert // A copy of a client.Object is a client.Object.
+		g.Expect(k8sCache.Get(ctx, client.ObjectKeyFromObject(o), got)).To(Succeed())
+		g.Expect(got.GetResourceVersion()).To(Equal(o.GetResourceVersion()))
+	}).Should(Succeed())
+}
+
+// deleteAll deletes every object of the supplied kind in the supplied
+// namespace, and waits for the cache to see them go.
+func deleteAll(o client.Object, l client.ObjectList, namespace string) {
+	GinkgoHelper()
+	Expect(k8sClient.DeleteAllOf(ctx, o, client.InNamespace(namespace))).To(Succeed())
+	Eventually(func(g Gomega) {
+		g.Expect(k8sCache.List(ctx, l, client.InNamespace(namespace))).To(Succeed())
+		g.Expect(apimeta.LenList(l)).To(BeZero())
+	}).Should(Succeed())
+}
+
+// getFirstFoundEnvTestBinaryDir locates the first binary in the specified path.
+// ENVTEST-based tests depend on specific binaries, usually located in paths set by
+// controller-runtime. When running tests directly (e.g., via an IDE) without using
+// Makefile targets, the 'BinaryAssetsDirectory' must be explicitly configured.
+//
+// This function streamlines the process by finding the required binaries, similar to
+// setting the 'KUBEBUILDER_ASSETS' environment variable. To ensure the binaries are
+// properly set up, run 'make setup-envtest' beforehand.
+func getFirstFoundEnvTestBinaryDir() string {
+	basePath := filepath.Join("..", "..", "..", "bin", "k8s")
+	entries, err := os.ReadDir(basePath)
+	if err != nil {
+		logf.Log.Error(err, "Failed to read directory", "path", basePath)
+		return ""
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			return filepath.Join(basePath, entry.Name())
+		}
+	}
+	return ""
+}
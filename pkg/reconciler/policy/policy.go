@@ -0,0 +1,35 @@
+// Package policy interprets the management policies of managed resources,
+// which limit what the handlers may do to the resources they manage.
+package policy
+
+import (
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// Allows returns true if the management policies of the supplied managed
+// resource allow the supplied action. A resource without management policies
+// allows every action.
+func Allows(mg resource.Managed, a xpv1.ManagementAction) bool {
+	ps := mg.GetManagementPolicies()
+	if len(ps) == 0 {
+		return true
+	}
+	for _, p := range ps {
+		if p == xpv1.ManagementActionAll || p == a {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns an error if the management policies of the supplied managed
+// resource do not allow the supplied action.
+func Check(mg resource.Managed, a xpv1.ManagementAction) error {
+	if Allows(mg, a) {
+		return nil
+	}
+	return errors.Errorf("management policies %v do not allow %s", mg.GetManagementPolicies(), a)
+}
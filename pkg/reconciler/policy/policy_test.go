@@ -0,0 +1,79 @@
+package policy
+
+import (
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+)
+
+func TestAllows(t *testing.T) {
+	actions := []xpv1.ManagementAction{
+		xpv1.ManagementActionObserve,
+		xpv1.ManagementActionCreate,
+		xpv1.ManagementActionUpdate,
+		xpv1.ManagementActionDelete,
+		xpv1.ManagementActionLateInitialize,
+	}
+
+	cases := map[string]struct {
+		policies xpv1.ManagementPolicies
+		want     map[xpv1.ManagementAction]bool
+	}{
+		"Unset": {
+			want: map[xpv1.ManagementAction]bool{
+				xpv1.ManagementActionObserve: true, xpv1.ManagementActionCreate: true, xpv1.ManagementActionUpdate: true,
+				xpv1.ManagementActionDelete: true, xpv1.ManagementActionLateInitialize: true,
+			},
+		},
+		"All": {
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionAll},
+			want: map[xpv1.ManagementAction]bool{
+				xpv1.ManagementActionObserve: true, xpv1.ManagementActionCreate: true, xpv1.ManagementActionUpdate: true,
+				xpv1.ManagementActionDelete: true, xpv1.ManagementActionLateInitialize: true,
+			},
+		},
+		"ObserveOnly": {
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve},
+			want:     map[xpv1.ManagementAction]bool{xpv1.ManagementActionObserve: true},
+		},
+		"ObserveAndLateInitialize": {
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionLateInitialize},
+			want:     map[xpv1.ManagementAction]bool{xpv1.ManagementActionObserve: true, xpv1.ManagementActionLateInitialize: true},
+		},
+		"NoLateInitialize": {
+			policies: xpv1.ManagementPolicies{
+				xpv1.ManagementActionObserve, xpv1.ManagementActionCreate, xpv1.ManagementActionUpdate, xpv1.ManagementActionDelete,
+			},
+			want: map[xpv1.ManagementAction]bool{
+				xpv1.ManagementActionObserve: true, xpv1.ManagementActionCreate: true, xpv1.ManagementActionUpdate: true,
+				xpv1.ManagementActionDelete: true,
+			},
+		},
+		"NoDelete": {
+			policies: xpv1.ManagementPolicies{
+				xpv1.ManagementActionObserve, xpv1.ManagementActionCreate, xpv1.ManagementActionUpdate, xpv1.ManagementActionLateInitialize,
+			},
+			want: map[xpv1.ManagementAction]bool{
+				xpv1.ManagementActionObserve: true, xpv1.ManagementActionCreate: true, xpv1.ManagementActionUpdate: true,
+				xpv1.ManagementActionLateInitialize: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &v1alpha1.Ride{}
+			r.SetManagementPolicies(tc.policies)
+			for _, a := range actions {
+				if got := Allows(r, a); got != tc.want[a] {
+					t.Errorf("Allows(%s): want %t, got %t", a, tc.want[a], got)
+				}
+				if err := Check(r, a); (err == nil) != tc.want[a] {
+					t.Errorf("Check(%s): want error %t, got %v", a, !tc.want[a], err)
+				}
+			}
+		})
+	}
+}
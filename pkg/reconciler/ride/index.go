@@ -0,0 +1,59 @@
+package ride
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	namespaced "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+)
+
+// Fields the Cache of a ConnectorWrapper must be indexed by.
+const (
+	// IndexKeyOperatorRide indexes RideOperators by the name of the Ride they
+	// are assigned to.
+	IndexKeyOperatorRide = "spec.forProvider.ride.name"
+
+	// IndexKeyMaintenanceWindowRide indexes MaintenanceWindows by the names of
+	// the Rides they apply to.
+	IndexKeyMaintenanceWindowRide = "spec.rides.name"
+)
+
+// SetupIndexes adds the fields the Cache of a ConnectorWrapper must be indexed
+// by to the supplied indexer.
+func SetupIndexes(ctx context.Context, i client.FieldIndexer) error {
+	if err := i.IndexField(ctx, &v1alpha1.RideOperator{}, IndexKeyOperatorRide, func(o client.Object) []string {
+		return operatorRide(o.(*v1alpha1.RideOperator).Spec.ForProvider.Ride) //nolint:forcetypeassert // Only called for RideOperators.
+	}); err != nil {
+		return errors.Wrap(err, "cannot index RideOperators by Ride")
+	}
+	if err := i.IndexField(ctx, &namespaced.RideOperator{}, IndexKeyOperatorRide, func(o client.Object) []string {
+		return operatorRide(o.(*namespaced.RideOperator).Spec.ForProvider.Ride) //nolint:forcetypeassert // Only called for RideOperators.
+	}); err != nil {
+		return errors.Wrap(err, "cannot index namespaced RideOperators by Ride")
+	}
+	if err := i.IndexField(ctx, &v1alpha1.MaintenanceWindow{}, IndexKeyMaintenanceWindowRide, func(o client.Object) []string {
+		mw := o.(*v1alpha1.MaintenanceWindow) //nolint:forcetypeassert // Only called for MaintenanceWindows.
+		names := make([]string, 0, len(mw.Spec.Rides))
+		for _, ref := range mw.Spec.Rides {
+			names = append(names, ref.Name)
+		}
+		return names
+	}); err != nil {
+		return errors.Wrap(err, "cannot index MaintenanceWindows by Ride")
+	}
+	return nil
+}
+
+// operatorRide returns the name of the supplied Ride as an index value, if a
+// RideOperator is assigned to one.
+func operatorRide(ref *xpv1.TypedReference) []string {
+	if ref == nil {
+		return nil
+	}
+	return []string{ref.Name}
+}
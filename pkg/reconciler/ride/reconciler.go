@@ -2,22 +2,76 @@ package ride
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
+	namespaced "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
 	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/pkg/catalog"
+	"github.com/n3wscott/theme-park-provider/pkg/park"
+	"github.com/n3wscott/theme-park-provider/pkg/reconciler/config"
+	"github.com/n3wscott/theme-park-provider/pkg/reconciler/policy"
+	"github.com/n3wscott/theme-park-provider/pkg/requeue"
 )
 
 // ConnectorWrapper wraps the connector for gRPC support.
 type ConnectorWrapper struct {
 	Log logging.Logger
+
+	// Client is used to resolve the ProviderConfig of a Ride, track its usage
+	// and look up the Park it is in.
+	Client client.Client
+
+	// Cache is used to look up the MaintenanceWindows that apply to a Ride
+	// and the RideOperators assigned to it, which are listed on every
+	// observation. It must be indexed by SetupIndexes.
+	Cache client.Reader
+
+	// Catalog of ride types used to initialize parameters the user leaves
+	// unset. Defaults to catalog.Default.
+	Catalog catalog.Catalog
+
+	// Requeue is used to reconcile a Ride when its operators' shifts start or
+	// end, its Park opens or closes, or its maintenance starts or ends. It is
+	// also used to reconcile its Park when it starts or stops operating, and
+	// its operators when they are freed by maintenance. Rides are only
+	// reconciled when polled if it is nil.
+	Requeue *requeue.Scheduler
+
+	// Queue reports how many guests are queuing for a Ride. Defaults to the
+	// queue length the park reports for the ride.
+	Queue QueueSource
+
+	// MaxWaitMinutes is the longest guests should expect to queue for a Ride
+	// that does not specify its own. Defaults to DefaultMaxWaitMinutes.
+	MaxWaitMinutes int
+}
+
+// DefaultMaxWaitMinutes is the longest guests should expect to queue for a
+// Ride when neither it nor the provider specify otherwise.
+const DefaultMaxWaitMinutes = 60
+
+// AnnotationKeyForceDelete allows a Ride to be deleted while RideOperators are
+// still assigned to it when set to "true".
+const AnnotationKeyForceDelete = "themepark.n3wscott.com/force-delete"
+
+// A QueueSource reports how many guests are queuing for a Ride.
+type QueueSource interface {
+	QueueLength(ctx context.Context, r *v1alpha1.Ride) (int, error)
 }
 
 // Connect implements the TypedExternalConnector interface.
@@ -26,46 +80,83 @@ func (c *ConnectorWrapper) Connect(ctx context.Context, mg resource.Managed) (ma
 	if log == nil {
 		log = logging.NewNopLogger()
 	}
-	conn := &connector{log: log}
+	cat := c.Catalog
+	if cat == nil {
+		cat = catalog.Default
+	}
+	maxWait := c.MaxWaitMinutes
+	if maxWait == 0 {
+		maxWait = DefaultMaxWaitMinutes
+	}
+	conn := &connector{log: log, kube: c.Client, cache: c.Cache, catalog: cat, requeue: c.Requeue, queue: c.Queue, maxWait: maxWait}
 	return conn.Connect(ctx, mg)
 }
 
 // connector satisfies the resource.ExternalConnector interface.
 type connector struct {
-	log logging.Logger
+	log     logging.Logger
+	kube    client.Client
+	cache   client.Reader
+	catalog catalog.Catalog
+	requeue *requeue.Scheduler
+	queue   QueueSource
+	maxWait int
 }
 
 // Connect to the supplied resource.Managed (presumed to be a Ride) by using the Provider.
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
 	c.log.Debug("Connecting to provider")
 
-	i, ok := mg.(*v1alpha1.Ride)
-	if !ok {
-		return nil, errors.New("managed resource is not a Ride")
+	if _, _, err := asRide(mg); err != nil {
+		return nil, err
 	}
 
-	i.Status.SetConditions(Connecting())
+	if c.kube == nil {
+		return nil, errors.New("no Kubernetes client configured for Ride")
+	}
+	if c.cache == nil {
+		return nil, errors.New("no Kubernetes cache configured for Ride")
+	}
 
-	return &external{log: c.log}, nil
+	pc, err := config.Connect(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{log: c.log, kube: c.kube, cache: c.cache, park: pc, catalog: c.catalog, requeue: c.requeue, queue: c.queue, maxWait: c.maxWait}, nil
 }
 
-const TypeOperational xpv1.ConditionType = "Operational"
+const (
+	TypeOperational    xpv1.ConditionType = "Operational"
+	TypeQueueSaturated xpv1.ConditionType = "QueueSaturated"
+)
 
-func Connecting() xpv1.Condition {
+// Reasons a Ride is or isn't operational.
+const (
+	ReasonOperating           xpv1.ConditionReason = "Operating"
+	ReasonPartiallyStaffed    xpv1.ConditionReason = "PartiallyStaffed"
+	ReasonShortStaffed        xpv1.ConditionReason = "ShortStaffed"
+	ReasonUncertifiedOperator xpv1.ConditionReason = "UncertifiedOperator"
+	ReasonParkClosed          xpv1.ConditionReason = "ParkClosed"
+	ReasonUnderMaintenance    xpv1.ConditionReason = "UnderMaintenance"
+	ReasonBrokenDown          xpv1.ConditionReason = "BrokenDown"
+)
+
+func Operating() xpv1.Condition {
 	return xpv1.Condition{
 		Type:               TypeOperational,
-		Status:             corev1.ConditionUnknown,
+		Status:             corev1.ConditionTrue,
 		LastTransitionTime: metav1.Now(),
-		Reason:             xpv1.ReasonCreating,
+		Reason:             ReasonOperating,
 	}
 }
 
-func Operating() xpv1.Condition {
+func PartiallyStaffed() xpv1.Condition {
 	return xpv1.Condition{
 		Type:               TypeOperational,
-		Status:             corev1.ConditionTrue,
+		Status:             corev1.ConditionFalse,
 		LastTransitionTime: metav1.Now(),
-		Reason:             "Operating",
+		Reason:             ReasonPartiallyStaffed,
 	}
 }
 
@@ -74,13 +165,145 @@ func ShortStaffed() xpv1.Condition {
 		Type:               TypeOperational,
 		Status:             corev1.ConditionFalse,
 		LastTransitionTime: metav1.Now(),
-		Reason:             "ShortStaffed",
+		Reason:             ReasonShortStaffed,
+	}
+}
+
+// UncertifiedOperator indicates a Ride can't operate because too few of the
+// operators assigned to it are certified to operate its type.
+func UncertifiedOperator(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeOperational,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUncertifiedOperator,
+		Message:            message,
+	}
+}
+
+// ParkClosed indicates a Ride can't operate because the Park it is in is
+// closed.
+func ParkClosed(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeOperational,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonParkClosed,
+		Message:            message,
+	}
+}
+
+// UnderMaintenance indicates a Ride can't operate because it is under
+// maintenance.
+func UnderMaintenance() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeOperational,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUnderMaintenance,
+	}
+}
+
+// BrokenDown indicates a Ride can't operate because the park reports it has a
+// fault that has not been cleared.
+func BrokenDown(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeOperational,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonBrokenDown,
+		Message:            message,
+	}
+}
+
+// Reasons a Ride's queue is or isn't saturated.
+const (
+	ReasonWaitTooLong  xpv1.ConditionReason = "WaitTooLong"
+	ReasonNotOperating xpv1.ConditionReason = "NotOperating"
+	ReasonWaitOK       xpv1.ConditionReason = "WaitOK"
+)
+
+// QueueSaturated indicates guests queuing for a Ride will wait longer than
+// they should.
+func QueueSaturated(reason xpv1.ConditionReason, message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeQueueSaturated,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+// QueueNotSaturated indicates guests queuing for a Ride won't wait longer
+// than they should.
+func QueueNotSaturated() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeQueueSaturated,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWaitOK,
+	}
+}
+
+// UnknownRideType indicates a Ride's type is not in the ride catalog, so it
+// cannot be built.
+func UnknownRideType(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "UnknownRideType",
+		Message:            message,
+	}
+}
+
+// DeletionBlocked indicates a Ride can't be deleted because RideOperators are
+// still assigned to it.
+func DeletionBlocked(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "DeletionBlocked",
+		Message:            message,
+	}
+}
+
+// asRide returns the supplied managed resource as a cluster-scoped Ride. A
+// namespaced Ride is returned as the cluster-scoped Ride it is managed as, and
+// done must be called to update it with any changes made to that Ride.
+func asRide(mg resource.Managed) (*v1alpha1.Ride, func(), error) {
+	switch r := mg.(type) {
+	case *v1alpha1.Ride:
+		return r, func() {}, nil
+	case *namespaced.Ride:
+		cr := r.ToCluster()
+		return cr, func() { r.FromCluster(cr) }, nil
+	default:
+		return nil, nil, errors.New("managed resource is not a Ride")
 	}
 }
 
+// operatorObject returns the object to requeue for the supplied RideOperator,
+// which is a namespaced RideOperator if it has a namespace.
+func operatorObject(ro *v1alpha1.RideOperator) client.Object {
+	if ro.GetNamespace() == "" {
+		return ro
+	}
+	return &namespaced.RideOperator{ObjectMeta: ro.ObjectMeta}
+}
+
 // External satisfies the resource.ExternalClient interface.
 type external struct {
-	log logging.Logger
+	log     logging.Logger
+	kube    client.Reader
+	cache   client.Reader
+	park    *park.Client
+	catalog catalog.Catalog
+	requeue *requeue.Scheduler
+	queue   QueueSource
+	maxWait int
 }
 
 // Observe the existing external resource, if any. The managed.Reconciler
@@ -90,20 +313,71 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	gvk := mg.GetObjectKind().GroupVersionKind().String()
 	e.log.Debug("Observing", "type", gvk)
 
-	i, ok := mg.(*v1alpha1.Ride)
-	if !ok {
-		return managed.ExternalObservation{}, errors.New("managed resource is not a Ride")
+	i, done, err := asRide(mg)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	defer done()
+
+	// Reject types the park can't build, unless the Ride is going away anyway.
+	if _, ok := e.catalog.Lookup(i.Spec.ForProvider.Type); !ok && !meta.WasDeleted(i) {
+		msg := fmt.Sprintf("ride type %q is not in the ride catalog, known types are %s",
+			i.Spec.ForProvider.Type, strings.Join(e.catalog.Names(), ", "))
+		i.SetConditions(UnknownRideType(msg))
+		return managed.ExternalObservation{}, errors.New(msg)
+	}
+
+	// The external name is the ID the park assigned to the ride when it was
+	// created. Setting it before the Ride is created adopts an existing ride.
+	id := meta.GetExternalName(i)
+	if id == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	pr, err := e.park.GetRide(ctx, id)
+	if park.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, "cannot get ride from park")
 	}
 
-	_ = i
+	i.SetConditions(xpv1.Available())
+
+	// Parameters the user left unset are only filled in from the park when the
+	// management policies allow it.
+	lateInitialized := false
+	if policy.Allows(i, xpv1.ManagementActionLateInitialize) {
+		lateInitialized = lateInitialize(i, pr, e.catalog)
+	}
+
+	queue, err := e.queueLength(ctx, i, pr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	want, err := e.desiredState(ctx, i, pr, queue)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	e.requeue.At(mg, want.nextChange)
+
+	// Update applies the operational state along with the spec, but won't
+	// be called to do so when the management policies don't allow updates.
+	d := specDiff(i, pr)
+	if policy.Allows(i, xpv1.ManagementActionUpdate) {
+		d = append(d, want.diff(i)...)
+	} else {
+		e.applyState(ctx, i, want)
+	}
+	diff := strings.Join(d, "; ")
 
 	o := managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: false,
-		ConnectionDetails: managed.ConnectionDetails{
-			xpv1.ResourceCredentialsSecretUserKey:     []byte("user"),
-			xpv1.ResourceCredentialsSecretEndpointKey: []byte("host"),
-		},
+		ResourceExists:          true,
+		ResourceUpToDate:        diff == "",
+		ResourceLateInitialized: lateInitialized,
+		Diff:                    diff,
+		ConnectionDetails:       e.connectionDetails(pr),
 	}
 
 	return o, nil
@@ -116,16 +390,27 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	gvk := mg.GetObjectKind().GroupVersionKind().String()
 	e.log.Debug("Create", "type", gvk)
 
-	i, ok := mg.(*v1alpha1.Ride)
-	if !ok {
-		return managed.ExternalCreation{}, errors.New("managed resource is not a Ride")
+	i, done, err := asRide(mg)
+	if err != nil {
+		return managed.ExternalCreation{}, err
 	}
+	defer done()
+	if err := policy.Check(i, xpv1.ManagementActionCreate); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, "cannot create ride in park")
+	}
+
+	pr, err := e.park.CreateRide(ctx, toPark(i, e.catalog))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, "cannot create ride in park")
+	}
+	meta.SetExternalName(i, pr.ID)
+
 	// Indicate that we're about to create the instance. Remember ExternalClient
 	// authors can use a bespoke condition reason here in cases where Creating
 	// doesn't make sense.
 	i.SetConditions(xpv1.Creating())
 
-	return managed.ExternalCreation{ConnectionDetails: map[string][]byte{"ride": []byte("maybe")}}, nil
+	return managed.ExternalCreation{ConnectionDetails: e.connectionDetails(pr)}, nil
 }
 
 // Update the existing external resource to match the specifications of our
@@ -135,40 +420,34 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	gvk := mg.GetObjectKind().GroupVersionKind().String()
 	e.log.Debug("Update", "type", gvk)
 
-	i, ok := mg.(*v1alpha1.Ride)
-	if !ok {
-		return managed.ExternalUpdate{}, errors.New("managed resource is not a Ride")
+	i, done, err := asRide(mg)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	defer done()
+	// Observe applies the operational state itself when the provider may not
+	// change the ride in the park.
+	if !policy.Allows(i, xpv1.ManagementActionUpdate) {
+		return managed.ExternalUpdate{}, nil
 	}
 
-	i.Status.RidersPerHour = 0
-
-	ros := new(v1alpha1.RideOperatorList)
-
-	//if err := e.client.List(ctx, ros); err != nil {
-	//	return managed.ExternalUpdate{}, err
-	//}
+	pr := toPark(i, e.catalog)
+	pr.ID = meta.GetExternalName(i)
+	updated, err := e.park.UpdateRide(ctx, pr)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, "cannot update ride in park")
+	}
 
-	var ro *v1alpha1.RideOperator
-	// Look for an operator for this ride.
-	for _, x := range ros.Items {
-		if x.Spec.ForProvider.Ride.Name == i.GetName() {
-			ro = &x
-		}
+	queue, err := e.queueLength(ctx, i, updated)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
 	}
 
-	if ro != nil {
-		i.SetConditions(Operating())
-		i.Status.Operator = &xpv1.TypedReference{
-			APIVersion: ro.APIVersion,
-			Kind:       ro.Kind,
-			Name:       ro.Name,
-			UID:        ro.UID,
-		}
-		i.Status.RidersPerHour = i.Spec.ForProvider.Capacity * ro.Spec.ForProvider.Frequency
-	} else {
-		i.SetConditions(ShortStaffed())
-		i.Status.RidersPerHour = 0
+	want, err := e.desiredState(ctx, i, updated, queue)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
 	}
+	e.applyState(ctx, i, want)
 
 	return managed.ExternalUpdate{}, nil
 }
@@ -180,16 +459,165 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 	gvk := mg.GetObjectKind().GroupVersionKind().String()
 	e.log.Debug("Delete", "type", gvk)
 
-	i, ok := mg.(*v1alpha1.Ride)
-	if !ok {
-		return managed.ExternalDelete{}, errors.New("managed resource is not a Ride")
+	i, done, err := asRide(mg)
+	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+	defer done()
+
+	// Operators would be left assigned to a Ride that doesn't exist, so they
+	// must be reassigned first unless the deletion is forced.
+	ros, err := e.operatorsFor(ctx, i)
+	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+	if len(ros) > 0 && i.GetAnnotations()[AnnotationKeyForceDelete] != "true" {
+		names := make([]string, 0, len(ros))
+		for _, ro := range ros {
+			names = append(names, ro.GetName())
+		}
+		msg := fmt.Sprintf("RideOperators [%s] are still assigned to this Ride; reassign them or set the %s annotation to \"true\"",
+			strings.Join(names, ", "), AnnotationKeyForceDelete)
+		i.SetConditions(DeletionBlocked(msg))
+		return managed.ExternalDelete{}, errors.New(msg)
 	}
+
 	// Indicate that we're about to delete the instance.
 	i.SetConditions(xpv1.Deleting())
 
+	// A Ride without an external name was never created in the park, and one
+	// the provider may not delete is left in the park.
+	id := meta.GetExternalName(i)
+	if id == "" || !policy.Allows(i, xpv1.ManagementActionDelete) {
+		return managed.ExternalDelete{}, nil
+	}
+	if err := e.park.DeleteRide(ctx, id); err != nil && !park.IsNotFound(err) {
+		return managed.ExternalDelete{}, errors.Wrap(err, "cannot delete ride from park")
+	}
+
+	// Let the operators know they are no longer assigned to a ride.
+	now := time.Now()
+	for n := range ros {
+		e.requeue.At(operatorObject(&ros[n]), now)
+	}
+
 	return managed.ExternalDelete{}, nil
 }
 
 func (e *external) Disconnect(ctx context.Context) error {
 	return nil
 }
+
+// applyState applies the supplied operational state to the supplied Ride, and
+// lets the Ride's operators and Park know if they are affected by the change.
+func (e *external) applyState(ctx context.Context, r *v1alpha1.Ride, s operationalState) {
+	changed := len(s.diff(r)) > 0
+
+	// Let the operators know they are free to be reassigned, or not.
+	got := r.GetCondition(TypeOperational).Reason
+	if got != s.condition.Reason && (got == ReasonUnderMaintenance || s.condition.Reason == ReasonUnderMaintenance) {
+		e.requeueOperators(ctx, r)
+	}
+	s.apply(r)
+
+	// Let the Park know this Ride's contribution to it has changed.
+	if changed && s.park != nil {
+		e.requeue.At(s.park, time.Now())
+	}
+}
+
+// connectionDetails returns the details other systems need to control the
+// supplied ride in the park: its endpoint, and the token that authorizes access
+// to it.
+func (e *external) connectionDetails(pr *park.Ride) managed.ConnectionDetails {
+	return managed.ConnectionDetails{
+		xpv1.ResourceCredentialsSecretEndpointKey: []byte(e.park.RideEndpoint(pr.ID)),
+		xpv1.ResourceCredentialsSecretTokenKey:    []byte(pr.ControlToken),
+	}
+}
+
+// queueLength returns how many guests are queuing for the supplied Ride, which
+// the park reports as the supplied ride unless a QueueSource is configured.
+func (e *external) queueLength(ctx context.Context, r *v1alpha1.Ride, pr *park.Ride) (int, error) {
+	if e.queue == nil {
+		return pr.QueueLength, nil
+	}
+	n, err := e.queue.QueueLength(ctx, r)
+	return n, errors.Wrap(err, "cannot get queue length of ride")
+}
+
+// parkOf returns the Park the supplied Ride is in, if it references one that
+// exists.
+func (e *external) parkOf(ctx context.Context, r *v1alpha1.Ride) (*v1alpha1.Park, error) {
+	ref := r.Spec.ForProvider.ParkRef
+	if ref == nil {
+		return nil, nil
+	}
+
+	p := &v1alpha1.Park{}
+	err := e.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, p)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return p, errors.Wrapf(err, "cannot get Park %q", ref.Name)
+}
+
+// operatorsFor returns the RideOperators whose spec.forProvider.ride points at
+// the supplied Ride. Only namespaced RideOperators in the same namespace can be
+// assigned to a namespaced Ride, and are returned as cluster-scoped ones.
+func (e *external) operatorsFor(ctx context.Context, r *v1alpha1.Ride) ([]v1alpha1.RideOperator, error) {
+	assigned := client.MatchingFields{IndexKeyOperatorRide: r.GetName()}
+	if r.GetNamespace() == "" {
+		l := new(v1alpha1.RideOperatorList)
+		if err := e.cache.List(ctx, l, assigned); err != nil {
+			return nil, errors.Wrap(err, "cannot list RideOperators")
+		}
+		return l.Items, nil
+	}
+
+	l := new(namespaced.RideOperatorList)
+	if err := e.cache.List(ctx, l, client.InNamespace(r.GetNamespace()), assigned); err != nil {
+		return nil, errors.Wrap(err, "cannot list RideOperators")
+	}
+	var ros []v1alpha1.RideOperator
+	for n := range l.Items {
+		ros = append(ros, *l.Items[n].ToCluster())
+	}
+	return ros, nil
+}
+
+// requeueOperators requests a reconcile of every RideOperator assigned to the
+// supplied Ride.
+func (e *external) requeueOperators(ctx context.Context, r *v1alpha1.Ride) {
+	ros, err := e.operatorsFor(ctx, r)
+	if err != nil {
+		e.log.Debug("Cannot requeue RideOperators of Ride", "error", err)
+		return
+	}
+	now := time.Now()
+	for i := range ros {
+		e.requeue.At(operatorObject(&ros[i]), now)
+	}
+}
+
+// maintenanceOf returns the maintenance schedules of the supplied Ride: its
+// own, and those of every MaintenanceWindow that references it. A
+// MaintenanceWindow only references cluster-scoped Rides.
+func (e *external) maintenanceOf(ctx context.Context, r *v1alpha1.Ride) ([]v1alpha1.MaintenanceSchedule, error) {
+	var schedules []v1alpha1.MaintenanceSchedule
+	if m := r.Spec.ForProvider.Maintenance; m != nil {
+		schedules = append(schedules, *m)
+	}
+	if r.GetNamespace() != "" {
+		return schedules, nil
+	}
+
+	mws := new(v1alpha1.MaintenanceWindowList)
+	if err := e.cache.List(ctx, mws, client.MatchingFields{IndexKeyMaintenanceWindowRide: r.GetName()}); err != nil {
+		return nil, errors.Wrap(err, "cannot list MaintenanceWindows")
+	}
+	for _, mw := range mws.Items {
+		schedules = append(schedules, mw.Spec.MaintenanceSchedule)
+	}
+	return schedules, nil
+}
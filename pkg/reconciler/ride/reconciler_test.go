@@ -0,0 +1,941 @@
+package ride
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	namespaced "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/pkg/catalog"
+	"github.com/n3wscott/theme-park-provider/pkg/park"
+)
+
+var _ = Describe("Ride handler", func() {
+	const rideName = "test-ride"
+
+	var (
+		ride *v1alpha1.Ride
+		c    *ConnectorWrapper
+		ext  managed.TypedExternalClient[resource.Managed]
+	)
+
+	BeforeEach(func() {
+		ride = &v1alpha1.Ride{
+			ObjectMeta: metav1.ObjectMeta{Name: rideName},
+			Spec: v1alpha1.RideSpec{
+				ForProvider: v1alpha1.RideParameters{
+					Type:     "rollercoaster",
+					Capacity: ptr.To(24),
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, ride)).To(Succeed())
+		// The gRPC server supplies fully typed objects, the typed client does
+		// not.
+		ride.SetGroupVersionKind(v1alpha1.RideGroupVersionKind)
+
+		c = &ConnectorWrapper{Client: k8sClient, Cache: k8sCache}
+		var err error
+		ext, err = c.Connect(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		deleteAll(&v1alpha1.RideOperator{}, &v1alpha1.RideOperatorList{}, "")
+		Expect(k8sClient.DeleteAllOf(ctx, &v1alpha1.Park{})).To(Succeed())
+		deleteAll(&v1alpha1.MaintenanceWindow{}, &v1alpha1.MaintenanceWindowList{}, "")
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, ride))).To(Succeed())
+		rides, err := parkClient.ListRides(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		for _, pr := range rides {
+			Expect(parkClient.DeleteRide(ctx, pr.ID)).To(Succeed())
+		}
+	})
+
+	It("should track usage of its ProviderConfig", func() {
+		pcu := &v1alpha1.ProviderConfigUsage{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: string(ride.GetUID())}, pcu)).To(Succeed())
+		Expect(pcu.ProviderConfigReference.Name).To(Equal("default"))
+		Expect(pcu.ResourceReference.Name).To(Equal(rideName))
+	})
+
+	It("should manage the ride in the park", func() {
+		By("observing a Ride that does not exist in the park")
+		obs, err := ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceExists).To(BeFalse())
+
+		By("creating the ride")
+		_, err = ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		id := meta.GetExternalName(ride)
+		Expect(id).NotTo(BeEmpty())
+		obs, err = ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceExists).To(BeTrue())
+
+		By("changing the ride in the park out-of-band")
+		pr, err := parkClient.GetRide(ctx, id)
+		Expect(err).NotTo(HaveOccurred())
+		pr.Capacity = 10
+		_, err = parkClient.UpdateRide(ctx, *pr)
+		Expect(err).NotTo(HaveOccurred())
+
+		obs, err = ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceUpToDate).To(BeFalse())
+		Expect(obs.Diff).To(ContainSubstring("capacity: 10 -> 24"))
+
+		By("updating the ride")
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		pr, err = parkClient.GetRide(ctx, id)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pr.Capacity).To(Equal(24))
+
+		By("deleting the ride")
+		_, err = ext.Delete(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		obs, err = ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceExists).To(BeFalse())
+	})
+
+	It("should publish the ride's endpoint and control token", func() {
+		cre, err := ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		id := meta.GetExternalName(ride)
+		Expect(cre.ConnectionDetails).To(HaveKeyWithValue(xpv1.ResourceCredentialsSecretEndpointKey, []byte(parkServer.URL+"/rides/"+id)))
+		token := cre.ConnectionDetails[xpv1.ResourceCredentialsSecretTokenKey]
+		Expect(token).NotTo(BeEmpty())
+
+		By("publishing the same details when observed")
+		obs, err := ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ConnectionDetails).To(Equal(cre.ConnectionDetails))
+
+		By("authorizing access to the ride, and only the ride")
+		rc := park.NewClient(parkServer.URL, park.WithBearerToken(string(token)))
+		_, err = rc.GetRide(ctx, id)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = rc.ListRides(ctx)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should refuse deletion while operators are assigned", func() {
+		_, err := ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		id := meta.GetExternalName(ride)
+		create(newOperator("assigned-operator", 10, rideName))
+
+		By("blocking deletion")
+		_, err = ext.Delete(ctx, ride)
+		Expect(err).To(MatchError(ContainSubstring("assigned-operator")))
+		cond := ride.GetCondition(xpv1.TypeReady)
+		Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(xpv1.ConditionReason("DeletionBlocked")))
+		_, err = parkClient.GetRide(ctx, id)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("deleting when forced")
+		meta.AddAnnotations(ride, map[string]string{AnnotationKeyForceDelete: "true"})
+		_, err = ext.Delete(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ride.GetCondition(xpv1.TypeReady).Reason).To(Equal(xpv1.ReasonDeleting))
+		_, err = parkClient.GetRide(ctx, id)
+		Expect(park.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should report a ride deleted out-of-band as not existing", func() {
+		_, err := ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parkClient.DeleteRide(ctx, meta.GetExternalName(ride))).To(Succeed())
+
+		obs, err := ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceExists).To(BeFalse())
+	})
+
+	It("should adopt an existing ride named by its external name", func() {
+		pr, err := parkClient.CreateRide(ctx, park.Ride{Name: "existing", Type: "rollercoaster", Capacity: 24})
+		Expect(err).NotTo(HaveOccurred())
+		meta.SetExternalName(ride, pr.ID)
+
+		obs, err := ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceExists).To(BeTrue())
+
+		rides, err := parkClient.ListRides(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rides).To(HaveLen(1))
+	})
+
+	It("should late-initialize an unset capacity from the ride type", func() {
+		ride.Spec.ForProvider.Capacity = nil
+		Expect(k8sClient.Update(ctx, ride)).To(Succeed())
+
+		_, err := ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		pr, err := parkClient.GetRide(ctx, meta.GetExternalName(ride))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pr.Capacity).To(Equal(catalog.Default["rollercoaster"].Capacity))
+
+		obs, err := ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceLateInitialized).To(BeTrue())
+		Expect(ride.Spec.ForProvider.Capacity).To(Equal(ptr.To(pr.Capacity)))
+
+		By("persisting the late-initialized spec")
+		Expect(k8sClient.Update(ctx, ride)).To(Succeed())
+		obs, err = ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceLateInitialized).To(BeFalse())
+	})
+
+	It("should late-initialize an unset capacity from an adopted ride", func() {
+		pr, err := parkClient.CreateRide(ctx, park.Ride{Name: "existing", Type: "rollercoaster", Capacity: 30})
+		Expect(err).NotTo(HaveOccurred())
+		meta.SetExternalName(ride, pr.ID)
+		ride.Spec.ForProvider.Capacity = nil
+
+		obs, err := ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceLateInitialized).To(BeTrue())
+		Expect(ride.Spec.ForProvider.Capacity).To(Equal(ptr.To(30)))
+	})
+
+	It("should report its throughput at the park's capacity when it can't late-initialize", func() {
+		ride.Spec.ForProvider.Capacity = nil
+		ride.SetManagementPolicies(xpv1.ManagementPolicies{
+			xpv1.ManagementActionObserve, xpv1.ManagementActionCreate, xpv1.ManagementActionUpdate, xpv1.ManagementActionDelete,
+		})
+		Expect(k8sClient.Update(ctx, ride)).To(Succeed())
+
+		_, err := ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		create(newOperator("capacity-operator", 10, rideName))
+
+		obs, err := ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceLateInitialized).To(BeFalse())
+		Expect(ride.Spec.ForProvider.Capacity).To(BeNil())
+
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ride.GetCondition(TypeOperational).Reason).To(Equal(ReasonOperating))
+		Expect(ride.Status.RidersPerHour).To(Equal(catalog.Default["rollercoaster"].Capacity * 10))
+	})
+
+	It("should not late-initialize a capacity set by the user", func() {
+		_, err := ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+
+		obs, err := ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceLateInitialized).To(BeFalse())
+		Expect(ride.Spec.ForProvider.Capacity).To(Equal(ptr.To(24)))
+	})
+
+	It("should reject a ride type that is not in the catalog", func() {
+		ride.Spec.ForProvider.Type = "fun"
+
+		_, err := ext.Observe(ctx, ride)
+		Expect(err).To(MatchError(ContainSubstring(`ride type "fun" is not in the ride catalog`)))
+		cond := ride.GetCondition(xpv1.TypeReady)
+		Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(xpv1.ConditionReason("UnknownRideType")))
+		Expect(cond.Message).To(ContainSubstring("rollercoaster"))
+	})
+
+	DescribeTable("should reject an invalid capacity",
+		func(capacity int, message string) {
+			r := &v1alpha1.Ride{
+				ObjectMeta: metav1.ObjectMeta{Name: "invalid-ride"},
+				Spec: v1alpha1.RideSpec{
+					ForProvider: v1alpha1.RideParameters{
+						Type:     "rollercoaster",
+						Capacity: ptr.To(capacity),
+					},
+				},
+			}
+			err := k8sClient.Create(ctx, r)
+			Expect(kerrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("zero", 0, "capacity must be positive"),
+		Entry("negative", -5, "capacity must be positive"),
+		Entry("too large", 501, "capacity must be at most 500 riders per trip"),
+	)
+
+	It("should not allow its type to be changed", func() {
+		ride.Spec.ForProvider.Type = "carousel"
+
+		err := k8sClient.Update(ctx, ride)
+		Expect(kerrors.IsInvalid(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("type is immutable")))
+	})
+
+	It("should use the ride type's minimum crew and cycle time", func() {
+		c.Catalog = catalog.Catalog{
+			"rollercoaster": {
+				Capacity:    24,
+				CycleTime:   metav1.Duration{Duration: 5 * time.Minute},
+				MinimumCrew: 2,
+			},
+		}
+		var err error
+		ext, err = c.Connect(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+
+		for idx, name := range []string{"type-a", "type-b"} {
+			create(newOperator(name, 10, rideName))
+
+			_, err = ext.Update(ctx, ride)
+			Expect(err).NotTo(HaveOccurred())
+			if idx == 0 {
+				Expect(ride.GetCondition(TypeOperational).Reason).To(Equal(xpv1.ConditionReason("PartiallyStaffed")))
+			}
+		}
+
+		By("dispatching at most once per cycle")
+		Expect(ride.GetCondition(TypeOperational).Reason).To(Equal(xpv1.ConditionReason("Operating")))
+		Expect(ride.Status.RidersPerHour).To(Equal(24 * 12))
+	})
+
+	It("should flip from ShortStaffed to Operating when an operator is created", func() {
+		By("updating a Ride without operators")
+		_, err := ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+
+		cond := ride.GetCondition(TypeOperational)
+		Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(xpv1.ConditionReason("ShortStaffed")))
+		Expect(ride.Status.Operators).To(BeEmpty())
+		Expect(ride.Status.RidersPerHour).To(Equal(0))
+
+		By("creating an operator assigned to the Ride")
+		ro := newOperator("test-operator", 10, rideName)
+		create(ro)
+
+		By("creating an operator assigned to another Ride")
+		other := ro.DeepCopy()
+		other.Name = "other-operator"
+		other.Spec.ForProvider.Ride.Name = "other-ride"
+		create(other)
+
+		By("updating the Ride again")
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+
+		cond = ride.GetCondition(TypeOperational)
+		Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+		Expect(cond.Reason).To(Equal(xpv1.ConditionReason("Operating")))
+		Expect(ride.Status.Operators).To(HaveLen(1))
+		Expect(ride.Status.Operators[0].Name).To(Equal(ro.Name))
+		Expect(ride.Status.Operators[0].UID).To(Equal(ro.UID))
+		Expect(ride.Status.RidersPerHour).To(Equal(240))
+
+		By("persisting the observed status")
+		Expect(k8sClient.Status().Update(ctx, ride)).To(Succeed())
+	})
+
+	It("should aggregate throughput from every assigned operator", func() {
+		ride.Spec.ForProvider.MaxDispatchRate = ptr.To(25)
+		ride.Spec.ForProvider.MinimumCrew = ptr.To(3)
+		Expect(k8sClient.Update(ctx, ride)).To(Succeed())
+
+		_, err := ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+
+		for idx, name := range []string{"op-a", "op-b", "op-c"} {
+			create(newOperator(name, 10, rideName))
+
+			_, err = ext.Update(ctx, ride)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ride.Status.Operators).To(HaveLen(idx + 1))
+
+			if idx < 2 {
+				By("reporting PartiallyStaffed below the minimum crew")
+				Expect(ride.GetCondition(TypeOperational).Reason).To(Equal(xpv1.ConditionReason("PartiallyStaffed")))
+				Expect(ride.Status.RidersPerHour).To(Equal(0))
+			}
+		}
+
+		By("saturating at the maximum dispatch rate")
+		Expect(ride.GetCondition(TypeOperational).Reason).To(Equal(xpv1.ConditionReason("Operating")))
+		Expect(ride.Status.RidersPerHour).To(Equal(24 * 25))
+	})
+
+	It("should only count operators certified for the ride type", func() {
+		_, err := ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("assigning an operator without the certification")
+		uncertified := newOperator("uncertified-operator", 10, rideName)
+		uncertified.Spec.ForProvider.Certifications = nil
+		create(uncertified)
+
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		cond := ride.GetCondition(TypeOperational)
+		Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(xpv1.ConditionReason("UncertifiedOperator")))
+		Expect(cond.Message).To(ContainSubstring("uncertified-operator"))
+		Expect(ride.Status.Operators).To(HaveLen(1))
+		Expect(ride.Status.UncertifiedOperators).To(HaveLen(1))
+		Expect(ride.Status.UncertifiedOperators[0].Name).To(Equal("uncertified-operator"))
+		Expect(ride.Status.RidersPerHour).To(Equal(0))
+
+		By("assigning an operator whose certification has expired")
+		expired := newOperator("expired-operator", 10, rideName)
+		expired.Spec.ForProvider.Certifications[0].ExpiresAt = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+		create(expired)
+
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ride.GetCondition(TypeOperational).Reason).To(Equal(xpv1.ConditionReason("UncertifiedOperator")))
+		Expect(ride.Status.UncertifiedOperators).To(HaveLen(2))
+
+		By("assigning a certified operator")
+		certified := newOperator("certified-operator", 4, rideName)
+		certified.Spec.ForProvider.Certifications[0].ExpiresAt = &metav1.Time{Time: time.Now().Add(time.Hour)}
+		create(certified)
+
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ride.GetCondition(TypeOperational).Reason).To(Equal(xpv1.ConditionReason("Operating")))
+		Expect(ride.Status.Operators).To(HaveLen(3))
+		Expect(ride.Status.UncertifiedOperators).To(HaveLen(2))
+		Expect(ride.Status.RidersPerHour).To(Equal(24 * 4))
+	})
+
+	It("should only count operators on shift", func() {
+		_, err := ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+
+		tomorrow := v1alpha1.Weekday(time.Now().UTC().AddDate(0, 0, 1).Weekday().String())
+
+		offShift := newOperator("off-shift-operator", 10, rideName)
+		offShift.Spec.ForProvider.Shifts = []v1alpha1.WeeklyWindow{{Days: []v1alpha1.Weekday{tomorrow}, Start: "12:00", End: "13:00"}}
+		create(offShift)
+
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ride.GetCondition(TypeOperational).Reason).To(Equal(xpv1.ConditionReason("ShortStaffed")))
+		Expect(ride.Status.Operators).To(BeEmpty())
+
+		onShift := newOperator("on-shift-operator", 4, rideName)
+		onShift.Spec.ForProvider.Shifts = []v1alpha1.WeeklyWindow{{Start: "00:00", End: "00:00"}}
+		create(onShift)
+
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ride.GetCondition(TypeOperational).Reason).To(Equal(xpv1.ConditionReason("Operating")))
+		Expect(ride.Status.Operators).To(HaveLen(1))
+		Expect(ride.Status.Operators[0].Name).To(Equal("on-shift-operator"))
+		Expect(ride.Status.RidersPerHour).To(Equal(24 * 4))
+	})
+
+	It("should only operate while its Park is open", func() {
+		_, err := ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		create(newOperator("park-operator", 4, rideName))
+
+		By("referencing a Park that does not exist")
+		ride.Spec.ForProvider.ParkRef = &xpv1.Reference{Name: "test-park"}
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ride.GetCondition(TypeOperational).Reason).To(Equal(ReasonParkClosed))
+		Expect(ride.GetCondition(TypeOperational).Message).To(ContainSubstring("does not exist"))
+
+		By("closing the Park for the day")
+		p := &v1alpha1.Park{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-park"},
+			Spec: v1alpha1.ParkSpec{
+				ForProvider: v1alpha1.ParkParameters{
+					Name:         "Test Park",
+					ClosureDates: []v1alpha1.ClosureDate{v1alpha1.ClosureDate(time.Now().UTC().Format(time.DateOnly))},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, p)).To(Succeed())
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ride.GetCondition(TypeOperational).Reason).To(Equal(ReasonParkClosed))
+		Expect(ride.GetCondition(TypeOperational).Message).To(ContainSubstring("is closed"))
+		Expect(ride.Status.Operators).To(HaveLen(1))
+		Expect(ride.Status.RidersPerHour).To(BeZero())
+
+		By("opening the Park")
+		p.Spec.ForProvider.ClosureDates = nil
+		Expect(k8sClient.Update(ctx, p)).To(Succeed())
+		obs, err := ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.Diff).To(ContainSubstring("(ParkClosed) -> True (Operating)"))
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ride.GetCondition(TypeOperational).Reason).To(Equal(ReasonOperating))
+		Expect(ride.Status.RidersPerHour).To(Equal(24 * 4))
+	})
+
+	It("should free its operators while under maintenance", func() {
+		_, err := ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		create(newOperator("maintenance-operator", 4, rideName))
+
+		By("scheduling a one-off window of maintenance on the Ride")
+		ride.Spec.ForProvider.Maintenance = &v1alpha1.MaintenanceSchedule{
+			OneOff: []v1alpha1.OneOffWindow{{
+				Start: metav1.NewTime(time.Now().Add(-time.Hour)),
+				End:   metav1.NewTime(time.Now().Add(time.Hour)),
+			}},
+		}
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ride.GetCondition(TypeOperational).Reason).To(Equal(ReasonUnderMaintenance))
+		Expect(ride.Status.Operators).To(BeEmpty())
+		Expect(ride.Status.RidersPerHour).To(BeZero())
+
+		By("finishing the maintenance")
+		ride.Spec.ForProvider.Maintenance = nil
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ride.GetCondition(TypeOperational).Reason).To(Equal(ReasonOperating))
+		Expect(ride.Status.Operators).To(HaveLen(1))
+
+		By("scheduling recurring maintenance with a MaintenanceWindow")
+		mw := &v1alpha1.MaintenanceWindow{
+			ObjectMeta: metav1.ObjectMeta{Name: "daily-inspection"},
+			Spec: v1alpha1.MaintenanceWindowSpec{
+				Rides: []xpv1.Reference{{Name: rideName}},
+				MaintenanceSchedule: v1alpha1.MaintenanceSchedule{
+					Recurring: []v1alpha1.WeeklyWindow{{Start: "00:00", End: "00:00"}},
+				},
+			},
+		}
+		create(mw)
+		obs, err := ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.Diff).To(ContainSubstring("(Operating) -> False (UnderMaintenance)"))
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ride.GetCondition(TypeOperational).Reason).To(Equal(ReasonUnderMaintenance))
+		Expect(ride.Status.RidersPerHour).To(BeZero())
+	})
+
+	It("should estimate the wait from its queue and throughput", func() {
+		_, err := ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		id := meta.GetExternalName(ride)
+
+		By("reporting guests queuing for a Ride that is not operating")
+		_, err = parkClient.SetQueueLength(ctx, id, 120)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ride.Status.QueueLength).To(Equal(120))
+		Expect(ride.Status.EstimatedWaitMinutes).To(BeNil())
+		cond := ride.GetCondition(TypeQueueSaturated)
+		Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+		Expect(cond.Reason).To(Equal(ReasonNotOperating))
+
+		By("estimating the wait once the Ride is operating")
+		create(newOperator("queue-operator", 10, rideName))
+		obs, err := ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.Diff).To(ContainSubstring("estimatedWaitMinutes: unknown -> 30"))
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ride.Status.RidersPerHour).To(Equal(240))
+		Expect(ride.Status.EstimatedWaitMinutes).To(Equal(ptr.To(30)))
+		Expect(ride.GetCondition(TypeQueueSaturated).Status).To(Equal(corev1.ConditionFalse))
+
+		By("saturating when the wait is longer than the Ride's maximum")
+		ride.Spec.ForProvider.MaxWaitMinutes = ptr.To(20)
+		obs, err = ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.Diff).To(ContainSubstring("QueueSaturated: False (WaitOK) -> True (WaitTooLong)"))
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		cond = ride.GetCondition(TypeQueueSaturated)
+		Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+		Expect(cond.Reason).To(Equal(ReasonWaitTooLong))
+
+		By("noticing guests leaving the queue")
+		_, err = parkClient.SetQueueLength(ctx, id, 40)
+		Expect(err).NotTo(HaveOccurred())
+		obs, err = ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.Diff).To(ContainSubstring("queueLength: 120 -> 40"))
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ride.Status.EstimatedWaitMinutes).To(Equal(ptr.To(10)))
+		Expect(ride.GetCondition(TypeQueueSaturated).Status).To(Equal(corev1.ConditionFalse))
+	})
+
+	It("should use the provider's queue source and maximum wait", func() {
+		c = &ConnectorWrapper{Client: k8sClient, Cache: k8sCache, Queue: fixedQueue(100), MaxWaitMinutes: 5}
+		var err error
+		ext, err = c.Connect(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		create(newOperator("queue-operator", 10, rideName))
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(ride.Status.QueueLength).To(Equal(100))
+		Expect(ride.Status.EstimatedWaitMinutes).To(Equal(ptr.To(25)))
+		Expect(ride.GetCondition(TypeQueueSaturated).Reason).To(Equal(ReasonWaitTooLong))
+	})
+
+	It("should report faults while the park reports them", func() {
+		_, err := ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		id := meta.GetExternalName(ride)
+		create(newOperator("fault-operator", 10, rideName))
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ride.GetCondition(TypeOperational).Reason).To(Equal(ReasonOperating))
+		Expect(ride.Status.LastFault).To(BeNil())
+
+		By("breaking down when the park reports a fault")
+		_, err = parkClient.InjectFault(ctx, id, park.FaultEmergencyStop, "guest pressed e-stop")
+		Expect(err).NotTo(HaveOccurred())
+		obs, err := ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.Diff).To(ContainSubstring("(Operating) -> False (BrokenDown)"))
+		Expect(obs.Diff).To(ContainSubstring("faultCount: 0 -> 1"))
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		cond := ride.GetCondition(TypeOperational)
+		Expect(cond.Reason).To(Equal(ReasonBrokenDown))
+		Expect(cond.Message).To(ContainSubstring("guest pressed e-stop"))
+		Expect(ride.Status.RidersPerHour).To(BeZero())
+		Expect(ride.Status.Operators).To(HaveLen(1))
+		Expect(ride.Status.LastFault).NotTo(BeNil())
+		Expect(ride.Status.LastFault.Kind).To(Equal(string(park.FaultEmergencyStop)))
+		Expect(ride.Status.LastFault.ClearedAt).To(BeNil())
+		Expect(ride.Status.FaultCount).To(Equal(1))
+
+		By("persisting the fault")
+		Expect(k8sClient.Status().Update(ctx, ride)).To(Succeed())
+		obs, err = ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceUpToDate).To(BeTrue())
+
+		By("operating again once the fault is cleared")
+		_, err = parkClient.ClearFault(ctx, id)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ride.GetCondition(TypeOperational).Reason).To(Equal(ReasonOperating))
+		Expect(ride.Status.LastFault.ClearedAt).NotTo(BeNil())
+		Expect(ride.Status.FaultCount).To(Equal(1))
+	})
+
+	It("should only report drift when the operational state changes", func() {
+		_, err := ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("observing a Ride that has never been updated")
+		obs, err := ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceUpToDate).To(BeFalse())
+		Expect(obs.Diff).To(ContainSubstring("ShortStaffed"))
+
+		By("observing the Ride after an update")
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		obs, err = ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceUpToDate).To(BeTrue())
+		Expect(obs.Diff).To(BeEmpty())
+
+		By("reconnecting does not introduce drift")
+		ext, err = c.Connect(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		obs, err = ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceUpToDate).To(BeTrue())
+
+		By("assigning an operator")
+		create(newOperator("drift-operator", 4, rideName))
+		obs, err = ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceUpToDate).To(BeFalse())
+		Expect(obs.Diff).To(ContainSubstring("operators: [] -> [drift-operator]"))
+		Expect(obs.Diff).To(ContainSubstring("ridersPerHour: 0 -> 96"))
+	})
+
+	DescribeTable("should only do what its management policies allow",
+		func(policies xpv1.ManagementPolicies, create, lateInit, update, del bool) {
+			ride.SetManagementPolicies(policies)
+
+			By("creating a ride")
+			_, cerr := ext.Create(ctx, ride.DeepCopy())
+			rides, err := parkClient.ListRides(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			if create {
+				Expect(cerr).NotTo(HaveOccurred())
+				Expect(rides).To(HaveLen(1))
+			} else {
+				Expect(cerr).To(MatchError(ContainSubstring("do not allow Create")))
+				Expect(rides).To(BeEmpty())
+			}
+
+			By("importing an existing ride")
+			pr, err := parkClient.CreateRide(ctx, park.Ride{Name: "existing", Type: "rollercoaster", Capacity: 30})
+			Expect(err).NotTo(HaveOccurred())
+			meta.SetExternalName(ride, pr.ID)
+			ride.Spec.ForProvider.Capacity = nil
+
+			obs, err := ext.Observe(ctx, ride)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(obs.ResourceExists).To(BeTrue())
+			Expect(obs.ResourceLateInitialized).To(Equal(lateInit))
+			if lateInit {
+				Expect(ride.Spec.ForProvider.Capacity).To(Equal(ptr.To(30)))
+			} else {
+				Expect(ride.Spec.ForProvider.Capacity).To(BeNil())
+			}
+
+			By("reporting its operational state")
+			if update {
+				Expect(obs.Diff).To(ContainSubstring("ShortStaffed"))
+			} else {
+				// Update won't be called, so Observe reports the state itself.
+				Expect(obs.Diff).NotTo(ContainSubstring("ShortStaffed"))
+				Expect(ride.GetCondition(TypeOperational).Reason).To(Equal(ReasonShortStaffed))
+			}
+
+			By("updating the ride")
+			ride.Spec.ForProvider.Capacity = ptr.To(24)
+			_, err = ext.Update(ctx, ride)
+			Expect(err).NotTo(HaveOccurred())
+			got, err := parkClient.GetRide(ctx, pr.ID)
+			Expect(err).NotTo(HaveOccurred())
+			if update {
+				Expect(got.Capacity).To(Equal(24))
+			} else {
+				Expect(got).To(Equal(pr))
+			}
+
+			By("deleting the ride")
+			_, err = ext.Delete(ctx, ride)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = parkClient.GetRide(ctx, pr.ID)
+			if del {
+				Expect(park.IsNotFound(err)).To(BeTrue())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("all actions", xpv1.ManagementPolicies{xpv1.ManagementActionAll}, true, true, true, true),
+		Entry("Observe only", xpv1.ManagementPolicies{xpv1.ManagementActionObserve}, false, false, false, false),
+		Entry("Observe and LateInitialize", xpv1.ManagementPolicies{
+			xpv1.ManagementActionObserve, xpv1.ManagementActionLateInitialize,
+		}, false, true, false, false),
+		Entry("all but LateInitialize", xpv1.ManagementPolicies{
+			xpv1.ManagementActionObserve, xpv1.ManagementActionCreate, xpv1.ManagementActionUpdate, xpv1.ManagementActionDelete,
+		}, true, false, true, true),
+		Entry("all but Create", xpv1.ManagementPolicies{
+			xpv1.ManagementActionObserve, xpv1.ManagementActionUpdate, xpv1.ManagementActionDelete, xpv1.ManagementActionLateInitialize,
+		}, false, true, true, true),
+		Entry("all but Update", xpv1.ManagementPolicies{
+			xpv1.ManagementActionObserve, xpv1.ManagementActionCreate, xpv1.ManagementActionDelete, xpv1.ManagementActionLateInitialize,
+		}, true, true, false, true),
+		Entry("all but Delete", xpv1.ManagementPolicies{
+			xpv1.ManagementActionObserve, xpv1.ManagementActionCreate, xpv1.ManagementActionUpdate, xpv1.ManagementActionLateInitialize,
+		}, true, true, true, false),
+	)
+})
+
+var _ = Describe("Namespaced Ride handler", func() {
+	const (
+		rideName       = "test-ride"
+		namespace      = "team-a"
+		otherNamespace = "team-b"
+	)
+
+	var (
+		ride *namespaced.Ride
+		ext  managed.TypedExternalClient[resource.Managed]
+	)
+
+	BeforeEach(func() {
+		createNamespace(namespace)
+		createNamespace(otherNamespace)
+
+		ride = &namespaced.Ride{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: rideName},
+			Spec: namespaced.RideSpec{
+				ResourceSpec: namespaced.ResourceSpec{
+					WriteConnectionSecretToReference: &xpv1.LocalSecretReference{Name: "test-ride-conn"},
+				},
+				ForProvider: namespaced.RideParameters{
+					Type:     "rollercoaster",
+					Capacity: ptr.To(24),
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, ride)).To(Succeed())
+		// The gRPC server supplies fully typed objects, the typed client does
+		// not.
+		ride.SetGroupVersionKind(namespaced.RideGroupVersionKind)
+
+		c := &ConnectorWrapper{Client: k8sClient, Cache: k8sCache}
+		var err error
+		ext, err = c.Connect(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		deleteAll(&v1alpha1.RideOperator{}, &v1alpha1.RideOperatorList{}, "")
+		for _, ns := range []string{namespace, otherNamespace} {
+			deleteAll(&namespaced.RideOperator{}, &namespaced.RideOperatorList{}, ns)
+			Expect(k8sClient.DeleteAllOf(ctx, &namespaced.Ride{}, client.InNamespace(ns))).To(Succeed())
+		}
+		rides, err := parkClient.ListRides(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		for _, pr := range rides {
+			Expect(parkClient.DeleteRide(ctx, pr.ID)).To(Succeed())
+		}
+	})
+
+	It("should track usage of its ProviderConfig in its namespace", func() {
+		pcu := &namespaced.ProviderConfigUsage{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: string(ride.GetUID())}, pcu)).To(Succeed())
+		Expect(pcu.ProviderConfigReference.Name).To(Equal("default"))
+		Expect(pcu.ResourceReference.APIVersion).To(Equal(namespaced.GroupVersion.String()))
+		Expect(pcu.ResourceReference.Name).To(Equal(rideName))
+	})
+
+	It("should not use a ProviderConfig in another namespace", func() {
+		Expect(k8sClient.Create(ctx, &namespaced.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Namespace: otherNamespace, Name: "other"},
+			Spec: namespaced.ProviderConfigSpec{
+				Endpoint:    parkServer.URL,
+				Credentials: namespaced.ProviderCredentials{Source: xpv1.CredentialsSourceNone},
+			},
+		})).To(Succeed())
+
+		r := ride.DeepCopy()
+		r.SetProviderConfigReference(&xpv1.Reference{Name: "other"})
+		c := &ConnectorWrapper{Client: k8sClient, Cache: k8sCache}
+		_, err := c.Connect(ctx, r)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should write its connection secret to its namespace", func() {
+		Expect(ride.GetWriteConnectionSecretToReference()).To(Equal(&xpv1.SecretReference{Namespace: namespace, Name: "test-ride-conn"}))
+	})
+
+	It("should manage the ride in the park", func() {
+		_, err := ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		id := meta.GetExternalName(ride)
+		Expect(id).NotTo(BeEmpty())
+
+		obs, err := ext.Observe(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceExists).To(BeTrue())
+		Expect(ride.GetCondition(xpv1.TypeReady).Reason).To(Equal(xpv1.ReasonAvailable))
+
+		_, err = ext.Delete(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = parkClient.GetRide(ctx, id)
+		Expect(park.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should only count operators in its namespace", func() {
+		_, err := ext.Create(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+
+		ro := newNamespacedOperator(namespace, "test-operator", 10, rideName)
+		create(ro)
+		create(newNamespacedOperator(otherNamespace, "other-operator", 10, rideName))
+		create(newOperator("cluster-operator", 10, rideName))
+
+		_, err = ext.Update(ctx, ride)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ride.GetCondition(TypeOperational).Reason).To(Equal(ReasonOperating))
+		Expect(ride.Status.Operators).To(Equal([]xpv1.TypedReference{{
+			APIVersion: namespaced.GroupVersion.String(),
+			Kind:       namespaced.RideOperatorKind,
+			Name:       ro.Name,
+			UID:        ro.UID,
+		}}))
+		Expect(ride.Status.RidersPerHour).To(Equal(240))
+
+		By("persisting the observed status")
+		Expect(k8sClient.Status().Update(ctx, ride)).To(Succeed())
+	})
+})
+
+// newOperator returns a RideOperator with the supplied frequency, assigned to
+// the supplied Ride and certified to operate rollercoasters.
+func newOperator(name string, frequency int, ride string) *v1alpha1.RideOperator {
+	return &v1alpha1.RideOperator{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.RideOperatorSpec{
+			ForProvider: v1alpha1.RideOperatorParameters{
+				Frequency:      frequency,
+				Certifications: []v1alpha1.Certification{{Name: "coaster-operations"}},
+				Ride: &xpv1.TypedReference{
+					APIVersion: v1alpha1.GroupVersion.String(),
+					Kind:       v1alpha1.RideKind,
+					Name:       ride,
+				},
+			},
+		},
+	}
+}
+
+// fixedQueue is a QueueSource that reports the same queue length for every
+// Ride.
+type fixedQueue int
+
+func (q fixedQueue) QueueLength(_ context.Context, _ *v1alpha1.Ride) (int, error) {
+	return int(q), nil
+}
+
+// newNamespacedOperator returns a namespaced RideOperator with the supplied
+// frequency, assigned to the supplied Ride in its namespace and certified to
+// operate rollercoasters.
+func newNamespacedOperator(namespace, name string, frequency int, ride string) *namespaced.RideOperator {
+	return &namespaced.RideOperator{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: namespaced.RideOperatorSpec{
+			ForProvider: namespaced.RideOperatorParameters{
+				Frequency:      frequency,
+				Certifications: []v1alpha1.Certification{{Name: "coaster-operations"}},
+				Ride: &xpv1.TypedReference{
+					APIVersion: namespaced.GroupVersion.String(),
+					Kind:       namespaced.RideKind,
+					Name:       ride,
+				},
+			},
+		},
+	}
+}
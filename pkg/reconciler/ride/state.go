@@ -0,0 +1,444 @@
+package ride
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	namespaced "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/pkg/catalog"
+	"github.com/n3wscott/theme-park-provider/pkg/park"
+	"github.com/n3wscott/theme-park-provider/pkg/schedule"
+)
+
+// operationalState is what a Ride should report in its status given its
+// maintenance, the Park it is in and the operators currently assigned to it.
+type operationalState struct {
+	// park is the Park the Ride is in, if any.
+	park *v1alpha1.Park
+
+	operators            []xpv1.TypedReference
+	uncertifiedOperators []xpv1.TypedReference
+	ridersPerHour        int
+	condition            xpv1.Condition
+
+	queueLength          int
+	estimatedWaitMinutes *int
+	queueCondition       xpv1.Condition
+
+	lastFault       *v1alpha1.RideFault
+	faultCount      int
+	downtimeMinutes int
+
+	// nextChange is when the state will next change regardless of any change
+	// to the Ride, its Park, its maintenance or its operators, e.g. because an
+	// operator's shift ends.
+	nextChange time.Time
+}
+
+// desiredState computes the operational state of the supplied Ride, which the
+// park reports as the supplied ride and which has the supplied number of guests
+// queuing for it.
+func (e *external) desiredState(ctx context.Context, r *v1alpha1.Ride, pr *park.Ride, queue int) (operationalState, error) {
+	ros, err := e.operatorsFor(ctx, r)
+	if err != nil {
+		return operationalState{}, err
+	}
+
+	// A Ride of an unknown type is only ever observed while it is being
+	// deleted, in which case the zero value is as good as any.
+	t, _ := e.catalog.Lookup(r.Spec.ForProvider.Type)
+
+	p, err := e.parkOf(ctx, r)
+	if err != nil {
+		return operationalState{}, err
+	}
+
+	ms, err := e.maintenanceOf(ctx, r)
+	if err != nil {
+		return operationalState{}, err
+	}
+
+	now := time.Now()
+	s := operationalState{park: p, operators: make([]xpv1.TypedReference, 0, len(ros))}
+	s.faults(pr, now)
+
+	under, next, err := schedule.UnderMaintenance(ms, now)
+	if err != nil {
+		return operationalState{}, err
+	}
+	s.changeAt(next)
+	if under {
+		// The operators of a Ride under maintenance are free to be
+		// reassigned, so none are reported.
+		s.condition = UnderMaintenance()
+		s.queue(queue, ptr.Deref(r.Spec.ForProvider.MaxWaitMinutes, e.maxWait))
+		return s, nil
+	}
+
+	closed := parkClosed(r, p, now, &s)
+	crew := make([]v1alpha1.RideOperator, 0, len(ros))
+	for _, ro := range ros {
+		on, next, err := schedule.OnShift(&ro, now)
+		if err != nil {
+			// The RideOperator reports its own invalid shifts.
+			e.log.Debug("Ignoring operator with invalid shifts", "operator", ro.GetName(), "error", err)
+			continue
+		}
+		s.changeAt(next)
+		if !on {
+			continue
+		}
+
+		ref := operatorRef(ro)
+		s.operators = append(s.operators, ref)
+		if !certified(ro, t, now) {
+			s.uncertifiedOperators = append(s.uncertifiedOperators, ref)
+			continue
+		}
+		crew = append(crew, ro)
+	}
+
+	switch {
+	case pr.LastFault.Active():
+		s.condition = BrokenDown(faultMessage(pr.LastFault))
+	case closed != "":
+		s.condition = ParkClosed(closed)
+	case len(crew) >= minimumCrew(r, t):
+		s.condition = Operating()
+		s.ridersPerHour = ridersPerHour(r, t, capacity(r, e.catalog), crew)
+	case len(s.uncertifiedOperators) > 0:
+		s.condition = UncertifiedOperator(fmt.Sprintf("operators [%s] are not certified to operate %s rides",
+			operatorNames(s.uncertifiedOperators), r.Spec.ForProvider.Type))
+	case len(crew) == 0:
+		s.condition = ShortStaffed()
+	default:
+		s.condition = PartiallyStaffed()
+	}
+	s.queue(queue, ptr.Deref(r.Spec.ForProvider.MaxWaitMinutes, e.maxWait))
+	return s, nil
+}
+
+// faults records the faults the park reports for the supplied ride, counting
+// the downtime of a fault that has not been cleared up to the supplied time.
+func (s *operationalState) faults(pr *park.Ride, now time.Time) {
+	s.faultCount = pr.Faults
+	downtime := pr.Downtime
+	if f := pr.LastFault; f != nil {
+		s.lastFault = &v1alpha1.RideFault{
+			Kind:    string(f.Kind),
+			Message: f.Message,
+			// Times are persisted to the second, so compare them that way.
+			At: metav1.NewTime(f.At.Truncate(time.Second)),
+		}
+		if f.ClearedAt != nil {
+			s.lastFault.ClearedAt = ptr.To(metav1.NewTime(f.ClearedAt.Truncate(time.Second)))
+		}
+		if f.Active() {
+			downtime += now.Sub(f.At)
+		}
+	}
+	s.downtimeMinutes = int(downtime / time.Minute)
+}
+
+// faultMessage returns a human-readable description of the supplied fault.
+func faultMessage(f *park.Fault) string {
+	if f.Message == "" {
+		return fmt.Sprintf("ride has a %s fault", f.Kind)
+	}
+	return fmt.Sprintf("ride has a %s fault: %s", f.Kind, f.Message)
+}
+
+// queue computes how long the supplied number of guests can expect to queue
+// at the state's throughput, and whether that is longer than the supplied
+// maximum wait.
+func (s *operationalState) queue(length, maxWait int) {
+	s.queueLength = length
+	switch {
+	case length == 0:
+		s.estimatedWaitMinutes = ptr.To(0)
+	case s.ridersPerHour > 0:
+		// Round up, a guest at the back of the queue waits for the last rider
+		// ahead of them to board.
+		s.estimatedWaitMinutes = ptr.To((length*60 + s.ridersPerHour - 1) / s.ridersPerHour)
+	default:
+		s.queueCondition = QueueSaturated(ReasonNotOperating,
+			fmt.Sprintf("%d guests are queuing for a ride that is not operating", length))
+		return
+	}
+	if wait := *s.estimatedWaitMinutes; wait > maxWait {
+		s.queueCondition = QueueSaturated(ReasonWaitTooLong,
+			fmt.Sprintf("estimated wait of %d minutes is longer than the maximum of %d minutes", wait, maxWait))
+		return
+	}
+	s.queueCondition = QueueNotSaturated()
+}
+
+// parkClosed returns why the supplied Ride can't operate at the supplied time
+// because of the supplied Park it is in, or an empty string if the Park is
+// open. A Ride that is not in a Park is never closed.
+func parkClosed(r *v1alpha1.Ride, p *v1alpha1.Park, now time.Time, s *operationalState) string {
+	ref := r.Spec.ForProvider.ParkRef
+	if ref == nil {
+		return ""
+	}
+	if p == nil {
+		return fmt.Sprintf("Park %q does not exist", ref.Name)
+	}
+	open, next, err := schedule.ParkOpen(p, now)
+	if err != nil {
+		// The Park reports its own invalid opening hours.
+		return fmt.Sprintf("Park %q has invalid opening hours: %s", ref.Name, err)
+	}
+	s.changeAt(next)
+	if !open {
+		return fmt.Sprintf("Park %q is closed", ref.Name)
+	}
+	return ""
+}
+
+// changeAt records that the state may change at the supplied time, unless it
+// is zero or the state changes sooner.
+func (s *operationalState) changeAt(t time.Time) {
+	if !t.IsZero() && (s.nextChange.IsZero() || t.Before(s.nextChange)) {
+		s.nextChange = t
+	}
+}
+
+// operatorRef returns a reference to the supplied RideOperator. The typed
+// client does not populate TypeMeta, so the kind and version are constant for
+// cluster-scoped and namespaced RideOperators.
+func operatorRef(ro v1alpha1.RideOperator) xpv1.TypedReference {
+	gv := v1alpha1.GroupVersion
+	if ro.GetNamespace() != "" {
+		gv = namespaced.GroupVersion
+	}
+	return xpv1.TypedReference{
+		APIVersion: gv.String(),
+		Kind:       v1alpha1.RideOperatorKind,
+		Name:       ro.Name,
+		UID:        ro.UID,
+	}
+}
+
+// certified returns true if the supplied RideOperator holds every
+// certification the supplied ride type requires, and none of them have expired
+// at the supplied time.
+func certified(ro v1alpha1.RideOperator, t catalog.RideType, now time.Time) bool {
+	for _, required := range t.Certifications {
+		held := false
+		for _, c := range ro.Spec.ForProvider.Certifications {
+			if c.Name == required && (c.ExpiresAt == nil || now.Before(c.ExpiresAt.Time)) {
+				held = true
+				break
+			}
+		}
+		if !held {
+			return false
+		}
+	}
+	return true
+}
+
+// apply writes the operational state to the status of the supplied Ride.
+func (s operationalState) apply(r *v1alpha1.Ride) {
+	r.Status.Operators = s.operators
+	r.Status.UncertifiedOperators = s.uncertifiedOperators
+	r.Status.RidersPerHour = s.ridersPerHour
+	r.Status.QueueLength = s.queueLength
+	r.Status.EstimatedWaitMinutes = s.estimatedWaitMinutes
+	r.Status.LastFault = s.lastFault
+	r.Status.FaultCount = s.faultCount
+	r.Status.DowntimeMinutes = s.downtimeMinutes
+	r.SetConditions(s.condition, s.queueCondition)
+}
+
+// diff returns a human-readable description of each way the status of the
+// supplied Ride differs from the operational state.
+func (s operationalState) diff(r *v1alpha1.Ride) []string {
+	var d []string
+
+	if !sameOperators(r.Status.Operators, s.operators) {
+		d = append(d, fmt.Sprintf("operators: [%s] -> [%s]", operatorNames(r.Status.Operators), operatorNames(s.operators)))
+	}
+	if !sameOperators(r.Status.UncertifiedOperators, s.uncertifiedOperators) {
+		d = append(d, fmt.Sprintf("uncertifiedOperators: [%s] -> [%s]", operatorNames(r.Status.UncertifiedOperators), operatorNames(s.uncertifiedOperators)))
+	}
+	if got, want := r.Status.RidersPerHour, s.ridersPerHour; got != want {
+		d = append(d, fmt.Sprintf("ridersPerHour: %d -> %d", got, want))
+	}
+	if got, want := r.GetCondition(TypeOperational), s.condition; got.Status != want.Status || got.Reason != want.Reason {
+		d = append(d, fmt.Sprintf("%s: %s (%s) -> %s (%s)", TypeOperational, got.Status, got.Reason, want.Status, want.Reason))
+	}
+	if got, want := r.Status.QueueLength, s.queueLength; got != want {
+		d = append(d, fmt.Sprintf("queueLength: %d -> %d", got, want))
+	}
+	if got, want := r.Status.EstimatedWaitMinutes, s.estimatedWaitMinutes; !ptr.Equal(got, want) {
+		d = append(d, fmt.Sprintf("estimatedWaitMinutes: %s -> %s", minutes(got), minutes(want)))
+	}
+	if got, want := r.Status.LastFault, s.lastFault; !sameFault(got, want) {
+		d = append(d, fmt.Sprintf("lastFault: %s -> %s", faultSummary(got), faultSummary(want)))
+	}
+	if got, want := r.Status.FaultCount, s.faultCount; got != want {
+		d = append(d, fmt.Sprintf("faultCount: %d -> %d", got, want))
+	}
+	if got, want := r.Status.DowntimeMinutes, s.downtimeMinutes; got != want {
+		d = append(d, fmt.Sprintf("downtimeMinutes: %d -> %d", got, want))
+	}
+	if got, want := r.GetCondition(TypeQueueSaturated), s.queueCondition; got.Status != want.Status || got.Reason != want.Reason {
+		d = append(d, fmt.Sprintf("%s: %s (%s) -> %s (%s)", TypeQueueSaturated, got.Status, got.Reason, want.Status, want.Reason))
+	}
+
+	return d
+}
+
+// sameFault returns true if both faults are the same fault in the same state.
+func sameFault(a, b *v1alpha1.RideFault) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Kind != b.Kind || a.Message != b.Message || !a.At.Equal(&b.At) {
+		return false
+	}
+	if a.ClearedAt == nil || b.ClearedAt == nil {
+		return a.ClearedAt == b.ClearedAt
+	}
+	return a.ClearedAt.Equal(b.ClearedAt)
+}
+
+// faultSummary returns a human-readable summary of the supplied fault.
+func faultSummary(f *v1alpha1.RideFault) string {
+	switch {
+	case f == nil:
+		return "none"
+	case f.ClearedAt == nil:
+		return fmt.Sprintf("%s at %s", f.Kind, f.At.UTC().Format(time.RFC3339))
+	default:
+		return fmt.Sprintf("%s at %s (cleared)", f.Kind, f.At.UTC().Format(time.RFC3339))
+	}
+}
+
+// minutes returns a human-readable description of the supplied, possibly
+// unknown, number of minutes.
+func minutes(m *int) string {
+	if m == nil {
+		return "unknown"
+	}
+	return fmt.Sprintf("%d", *m)
+}
+
+// specDiff returns a human-readable description of each way the ride in the
+// park differs from the spec of the supplied Ride.
+func specDiff(r *v1alpha1.Ride, pr *park.Ride) []string {
+	var d []string
+	if got, want := pr.Type, r.Spec.ForProvider.Type; got != want {
+		d = append(d, fmt.Sprintf("type: %q -> %q", got, want))
+	}
+	if want := r.Spec.ForProvider.Capacity; want != nil && pr.Capacity != *want {
+		d = append(d, fmt.Sprintf("capacity: %d -> %d", pr.Capacity, *want))
+	}
+	return d
+}
+
+// toPark returns the ride the park should have for the supplied Ride. The ID is
+// left for the park to assign.
+func toPark(r *v1alpha1.Ride, c catalog.Catalog) park.Ride {
+	return park.Ride{
+		Name:     r.GetName(),
+		Type:     r.Spec.ForProvider.Type,
+		Capacity: capacity(r, c),
+	}
+}
+
+// capacity returns the riders per trip of the supplied Ride, falling back to
+// the capacity of its ride type if the user did not specify one.
+func capacity(r *v1alpha1.Ride, c catalog.Catalog) int {
+	if r.Spec.ForProvider.Capacity != nil {
+		return *r.Spec.ForProvider.Capacity
+	}
+	if t, ok := c.Lookup(r.Spec.ForProvider.Type); ok {
+		return t.Capacity
+	}
+	return 0
+}
+
+// lateInitialize fills in the capacity of the supplied Ride if the user did not
+// specify one, preferring the capacity of the ride in the park over that of the
+// ride type. It returns true if the spec was changed.
+func lateInitialize(r *v1alpha1.Ride, pr *park.Ride, c catalog.Catalog) bool {
+	if r.Spec.ForProvider.Capacity != nil {
+		return false
+	}
+	n := pr.Capacity
+	if n == 0 {
+		n = capacity(r, c)
+	}
+	if n == 0 {
+		return false
+	}
+	r.Spec.ForProvider.Capacity = ptr.To(n)
+	return true
+}
+
+// sameOperators returns true if both lists reference the same operators in the
+// same order. The UID is compared so that an operator that is deleted and
+// recreated with the same name is still detected as a change.
+func sameOperators(a, b []xpv1.TypedReference) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name || a[i].UID != b[i].UID {
+			return false
+		}
+	}
+	return true
+}
+
+// operatorNames returns the names of the supplied operators.
+func operatorNames(refs []xpv1.TypedReference) string {
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, ref.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// minimumCrew returns the number of operators the supplied Ride of the supplied
+// type needs before it can operate.
+func minimumCrew(r *v1alpha1.Ride, t catalog.RideType) int {
+	if r.Spec.ForProvider.MinimumCrew != nil {
+		return *r.Spec.ForProvider.MinimumCrew
+	}
+	if t.MinimumCrew > 0 {
+		return t.MinimumCrew
+	}
+	return 1
+}
+
+// ridersPerHour returns the throughput of the supplied Ride of the supplied
+// type and capacity when run by the supplied operators. Each operator
+// contributes their dispatch frequency, up to the ride's maximum dispatch rate
+// and no more than once per cycle of the ride type. The capacity must be the
+// one the park runs the ride at, which the Ride may not specify if it isn't
+// late initialized.
+func ridersPerHour(r *v1alpha1.Ride, t catalog.RideType, capacity int, ros []v1alpha1.RideOperator) int {
+	dispatches := 0
+	for _, ro := range ros {
+		dispatches += ro.Spec.ForProvider.Frequency
+	}
+	if limit := r.Spec.ForProvider.MaxDispatchRate; limit != nil && dispatches > *limit {
+		dispatches = *limit
+	}
+	if limit := t.MaxDispatchesPerHour(); limit > 0 && dispatches > limit {
+		dispatches = limit
+	}
+	return capacity * dispatches
+}
@@ -2,65 +2,153 @@ package rideoperator
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
+	namespaced "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
 	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/pkg/park"
+	"github.com/n3wscott/theme-park-provider/pkg/reconciler/config"
+	"github.com/n3wscott/theme-park-provider/pkg/reconciler/policy"
+	"github.com/n3wscott/theme-park-provider/pkg/reconciler/ride"
+	"github.com/n3wscott/theme-park-provider/pkg/requeue"
+	"github.com/n3wscott/theme-park-provider/pkg/schedule"
 )
 
 // ConnectorWrapper wraps the connector for gRPC support.
 type ConnectorWrapper struct {
 	Log logging.Logger
+
+	// Client is used to resolve the ProviderConfig of a RideOperator, track
+	// its usage and look up the park ID of the Ride it is assigned to.
+	Client client.Client
+
+	// Requeue is used to reconcile a RideOperator when its shifts start or
+	// end. RideOperators are only reconciled when polled if it is nil.
+	Requeue *requeue.Scheduler
 }
 
+// AnnotationKeyRotateCredentials requests that a RideOperator's console token
+// is rotated. Its value is an RFC 3339 time; the token is rotated once that
+// time has passed if it was issued before then.
+const AnnotationKeyRotateCredentials = "themepark.n3wscott.com/rotate-credentials"
+
+// DefaultCredentialOverlap is how long a RideOperator's previous console token
+// remains valid after it is rotated, unless its rotation policy says otherwise.
+const DefaultCredentialOverlap = 5 * time.Minute
+
 // Connect implements the TypedExternalConnector interface.
 func (c *ConnectorWrapper) Connect(ctx context.Context, mg resource.Managed) (managed.TypedExternalClient[resource.Managed], error) {
 	log := c.Log
 	if log == nil {
 		log = logging.NewNopLogger()
 	}
-	conn := &connector{log: log}
+	conn := &connector{log: log, kube: c.Client, requeue: c.Requeue}
 	return conn.Connect(ctx, mg)
 }
 
 // connector satisfies the resource.ExternalConnector interface.
 type connector struct {
-	log logging.Logger
+	log     logging.Logger
+	kube    client.Client
+	requeue *requeue.Scheduler
 }
 
 // Connect to the supplied resource.Managed (presumed to be a RideOperator) by using the Provider.
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
 	c.log.Debug("Connecting to provider")
 
-	i, ok := mg.(*v1alpha1.RideOperator)
-	if !ok {
-		return nil, errors.New("managed resource is not a RideOperator")
+	if _, _, err := asRideOperator(mg); err != nil {
+		return nil, err
+	}
+
+	if c.kube == nil {
+		return nil, errors.New("no Kubernetes client configured for RideOperator")
 	}
 
-	i.Status.SetConditions(Connecting())
+	pc, err := config.Connect(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
 
-	return &external{log: c.log}, nil
+	return &external{log: c.log, kube: c.kube, park: pc, requeue: c.requeue}, nil
 }
 
-func Connecting() xpv1.Condition {
+// RideNotFound indicates the Ride a RideOperator is assigned to does not exist.
+func RideNotFound(name string) xpv1.Condition {
 	return xpv1.Condition{
 		Type:               xpv1.TypeReady,
 		Status:             corev1.ConditionFalse,
 		LastTransitionTime: metav1.Now(),
-		Reason:             xpv1.ReasonCreating,
+		Reason:             "RideNotFound",
+		Message:            fmt.Sprintf("Ride %q does not exist", name),
+	}
+}
+
+// ReasonUnassigned indicates the Ride a RideOperator was assigned to has been
+// deleted.
+const ReasonUnassigned xpv1.ConditionReason = "Unassigned"
+
+// Unassigned indicates the Ride a RideOperator was assigned to has been
+// deleted, leaving it unassigned until it is assigned to another Ride.
+func Unassigned(name string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUnassigned,
+		Message:            fmt.Sprintf("Ride %q was deleted", name),
+	}
+}
+
+// RideDeleting indicates the Ride a RideOperator is assigned to is being
+// deleted.
+func RideDeleting(name string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "RideDeleting",
+		Message:            fmt.Sprintf("Ride %q is being deleted", name),
+	}
+}
+
+// asRideOperator returns the supplied managed resource as a cluster-scoped
+// RideOperator. A namespaced RideOperator is returned as the cluster-scoped
+// RideOperator it is managed as, and done must be called to update it with any
+// changes made to that RideOperator.
+func asRideOperator(mg resource.Managed) (*v1alpha1.RideOperator, func(), error) {
+	switch ro := mg.(type) {
+	case *v1alpha1.RideOperator:
+		return ro, func() {}, nil
+	case *namespaced.RideOperator:
+		cro := ro.ToCluster()
+		return cro, func() { ro.FromCluster(cro) }, nil
+	default:
+		return nil, nil, errors.New("managed resource is not a RideOperator")
 	}
 }
 
 // External satisfies the resource.ExternalClient interface.
 type external struct {
-	log logging.Logger
+	log     logging.Logger
+	kube    client.Reader
+	park    *park.Client
+	requeue *requeue.Scheduler
 }
 
 // Observe the existing external resource, if any. The managed.Reconciler
@@ -70,20 +158,93 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	gvk := mg.GetObjectKind().GroupVersionKind().String()
 	e.log.Debug("Observing", "type", gvk)
 
-	i, ok := mg.(*v1alpha1.RideOperator)
-	if !ok {
-		return managed.ExternalObservation{}, errors.New("managed resource is not a RideOperator")
+	i, done, err := asRideOperator(mg)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	defer done()
+
+	// The external name is the ID the park assigned to the operator when it was
+	// created. Setting it before the RideOperator is created adopts an
+	// existing operator.
+	id := meta.GetExternalName(i)
+	if id == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	po, err := e.park.GetOperator(ctx, id)
+	if park.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, "cannot get operator from park")
+	}
+
+	r, err := e.resolveRide(ctx, i)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	// An operator whose Ride existed when it was last observed, or who has
+	// already been unassigned, has had their Ride deleted from under them.
+	unassigned := i.Status.RideUID != "" || i.GetCondition(xpv1.TypeReady).Reason == ReasonUnassigned
+	i.Status.RideUID = ""
+	if r != nil {
+		i.Status.RideUID = r.GetUID()
+	}
+
+	now := time.Now()
+	on, next, err := schedule.OnShift(i, now)
+	if err != nil {
+		return managed.ExternalObservation{}, err
 	}
+	i.Status.OnShift = on
+	e.requeue.At(mg, next)
 
-	i.SetConditions(xpv1.Available())
+	// An operator on shift is free to be reassigned unless they are operating
+	// a ride.
+	i.Status.Available = on && (r == nil || r.GetCondition(ride.TypeOperational).Reason == ride.ReasonUnderMaintenance)
+
+	switch ref := i.Spec.ForProvider.Ride; {
+	case ref != nil && r == nil && unassigned:
+		i.SetConditions(Unassigned(ref.Name))
+	case ref != nil && r == nil:
+		i.SetConditions(RideNotFound(ref.Name))
+	case r != nil && meta.WasDeleted(r):
+		i.SetConditions(RideDeleting(r.GetName()))
+	default:
+		i.SetConditions(xpv1.Available())
+	}
+
+	want, err := toPark(i, r)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	d := specDiff(want, po)
+
+	i.Status.CredentialsIssuedAt = issuedAt(po)
+
+	// Only an operator the provider may update has its console token rotated.
+	if policy.Allows(i, xpv1.ManagementActionUpdate) {
+		rotate, at, err := rotationDue(i, po, now)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if rotate != "" {
+			d = append(d, "credentials: "+rotate)
+		}
+		// The console token may be due to rotate before the next shift starts
+		// or ends.
+		if !at.IsZero() && (next.IsZero() || at.Before(next)) {
+			e.requeue.At(mg, at)
+		}
+	}
+	diff := strings.Join(d, "; ")
 
 	o := managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: true,
-		ConnectionDetails: managed.ConnectionDetails{
-			xpv1.ResourceCredentialsSecretUserKey:     []byte("user"),
-			xpv1.ResourceCredentialsSecretEndpointKey: []byte("host"),
-		},
+		ResourceExists:    true,
+		ResourceUpToDate:  diff == "",
+		Diff:              diff,
+		ConnectionDetails: e.connectionDetails(po),
 	}
 
 	return o, nil
@@ -96,16 +257,36 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	gvk := mg.GetObjectKind().GroupVersionKind().String()
 	e.log.Debug("Create", "type", gvk)
 
-	i, ok := mg.(*v1alpha1.RideOperator)
-	if !ok {
-		return managed.ExternalCreation{}, errors.New("managed resource is not a RideOperator")
+	i, done, err := asRideOperator(mg)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	defer done()
+	if err := policy.Check(i, xpv1.ManagementActionCreate); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, "cannot create operator in park")
+	}
+
+	r, err := e.resolveRide(ctx, i)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	want, err := toPark(i, r)
+	if err != nil {
+		return managed.ExternalCreation{}, err
 	}
+	po, err := e.park.CreateOperator(ctx, want)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, "cannot create operator in park")
+	}
+	meta.SetExternalName(i, po.ID)
+	i.Status.CredentialsIssuedAt = issuedAt(po)
+
 	// Indicate that we're about to create the instance. Remember ExternalClient
 	// authors can use a bespoke condition reason here in cases where Creating
 	// doesn't make sense.
 	i.SetConditions(xpv1.Creating())
 
-	return managed.ExternalCreation{ConnectionDetails: map[string][]byte{"rideOperator": []byte("maybe")}}, nil
+	return managed.ExternalCreation{ConnectionDetails: e.connectionDetails(po)}, nil
 }
 
 // Update the existing external resource to match the specifications of our
@@ -115,14 +296,49 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	gvk := mg.GetObjectKind().GroupVersionKind().String()
 	e.log.Debug("Update", "type", gvk)
 
-	i, ok := mg.(*v1alpha1.RideOperator)
-	if !ok {
-		return managed.ExternalUpdate{}, errors.New("managed resource is not a RideOperator")
+	i, done, err := asRideOperator(mg)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	defer done()
+	// Observe reports the operator in the park as it is when the provider may
+	// not change it.
+	if !policy.Allows(i, xpv1.ManagementActionUpdate) {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	r, err := e.resolveRide(ctx, i)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	want, err := toPark(i, r)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	want.ID = meta.GetExternalName(i)
+	po, err := e.park.UpdateOperator(ctx, want)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, "cannot update operator in park")
 	}
 
-	_ = i
+	rotate, _, err := rotationDue(i, po, time.Now())
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if rotate != "" {
+		overlap := DefaultCredentialOverlap
+		if p := i.Spec.ForProvider.CredentialRotation; p != nil && p.Overlap != nil {
+			overlap = p.Overlap.Duration
+		}
+		po, err = e.park.RotateConsoleToken(ctx, po.ID, overlap)
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, "cannot rotate console token of operator in park")
+		}
+	}
+	i.Status.CredentialsIssuedAt = issuedAt(po)
 
-	return managed.ExternalUpdate{}, nil
+	// Update the connection secret in place, in case the token was rotated.
+	return managed.ExternalUpdate{ConnectionDetails: e.connectionDetails(po)}, nil
 }
 
 // Delete the external resource. managed.Reconciler only calls Delete
@@ -132,16 +348,151 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 	gvk := mg.GetObjectKind().GroupVersionKind().String()
 	e.log.Debug("Delete", "type", gvk)
 
-	i, ok := mg.(*v1alpha1.RideOperator)
-	if !ok {
-		return managed.ExternalDelete{}, errors.New("managed resource is not a RideOperator")
+	i, done, err := asRideOperator(mg)
+	if err != nil {
+		return managed.ExternalDelete{}, err
 	}
+	defer done()
 	// Indicate that we're about to delete the instance.
 	i.SetConditions(xpv1.Deleting())
 
+	// A RideOperator without an external name was never created in the park,
+	// and one the provider may not delete is left in the park.
+	id := meta.GetExternalName(i)
+	if id == "" || !policy.Allows(i, xpv1.ManagementActionDelete) {
+		return managed.ExternalDelete{}, nil
+	}
+	if err := e.park.DeleteOperator(ctx, id); err != nil && !park.IsNotFound(err) {
+		return managed.ExternalDelete{}, errors.Wrap(err, "cannot delete operator from park")
+	}
+
 	return managed.ExternalDelete{}, nil
 }
 
 func (e *external) Disconnect(ctx context.Context) error {
 	return nil
 }
+
+// connectionDetails returns the credentials the supplied operator in the park
+// signs in to the ride console with, and the endpoint they can read their
+// assignment from.
+func (e *external) connectionDetails(po *park.Operator) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{
+		xpv1.ResourceCredentialsSecretEndpointKey: []byte(e.park.OperatorEndpoint(po.ID)),
+	}
+	if c := po.Console; c != nil {
+		cd[xpv1.ResourceCredentialsSecretUserKey] = []byte(c.Username)
+		cd[xpv1.ResourceCredentialsSecretTokenKey] = []byte(c.Token)
+	}
+	return cd
+}
+
+// resolveRide returns the Ride the supplied RideOperator is assigned to, or nil
+// if it is not assigned to a Ride or the Ride does not exist. A namespaced
+// RideOperator is assigned to the namespaced Ride in its namespace, which is
+// returned as a cluster-scoped Ride.
+func (e *external) resolveRide(ctx context.Context, ro *v1alpha1.RideOperator) (*v1alpha1.Ride, error) {
+	ref := ro.Spec.ForProvider.Ride
+	if ref == nil {
+		return nil, nil
+	}
+
+	if ro.GetNamespace() != "" {
+		r := &namespaced.Ride{}
+		err := e.kube.Get(ctx, types.NamespacedName{Namespace: ro.GetNamespace(), Name: ref.Name}, r)
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot get Ride %q", ref.Name)
+		}
+		return r.ToCluster(), nil
+	}
+
+	r := &v1alpha1.Ride{}
+	err := e.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, r)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return r, errors.Wrapf(err, "cannot get Ride %q", ref.Name)
+}
+
+// toPark returns the operator the park should have for the supplied
+// RideOperator, assigned to the supplied Ride if it is not nil. The ID is left
+// for the park to assign.
+func toPark(ro *v1alpha1.RideOperator, r *v1alpha1.Ride) (park.Operator, error) {
+	o := park.Operator{
+		Name:      ro.GetName(),
+		Frequency: ro.Spec.ForProvider.Frequency,
+	}
+	if r == nil {
+		return o, nil
+	}
+	o.RideID = meta.GetExternalName(r)
+	if o.RideID == "" {
+		return park.Operator{}, errors.Errorf("Ride %q has not been created in the park yet", r.GetName())
+	}
+	return o, nil
+}
+
+// specDiff returns a human-readable description of each way the operator in
+// the park differs from the operator it should be.
+func specDiff(o park.Operator, po *park.Operator) []string {
+	var d []string
+	if got, want := po.RideID, o.RideID; got != want {
+		d = append(d, fmt.Sprintf("ride: %q -> %q", got, want))
+	}
+	if got, want := po.Frequency, o.Frequency; got != want {
+		d = append(d, fmt.Sprintf("frequency: %d -> %d", got, want))
+	}
+	return d
+}
+
+// rotationDue returns why the console token of the supplied operator in the
+// park should be rotated at the supplied time, or an empty string if it
+// shouldn't be. If it shouldn't be yet it also returns when it will be, if
+// ever.
+func rotationDue(ro *v1alpha1.RideOperator, po *park.Operator, now time.Time) (string, time.Time, error) {
+	c := po.Console
+	if c == nil {
+		return "no console token has been issued", time.Time{}, nil
+	}
+
+	var next time.Time
+	if v, ok := ro.GetAnnotations()[AnnotationKeyRotateCredentials]; ok {
+		requested, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return "", time.Time{}, errors.Wrapf(err, "cannot parse %s annotation", AnnotationKeyRotateCredentials)
+		}
+		// The requested time is only precise to the second.
+		switch {
+		case requested.After(now):
+			next = requested
+		case requested.After(c.IssuedAt.Truncate(time.Second)):
+			return fmt.Sprintf("rotation requested at %s", v), time.Time{}, nil
+		}
+	}
+
+	p := ro.Spec.ForProvider.CredentialRotation
+	if p == nil || p.MaxAge == nil {
+		return "", next, nil
+	}
+	expires := c.IssuedAt.Add(p.MaxAge.Duration)
+	if !now.Before(expires) {
+		return fmt.Sprintf("console token is older than %s", p.MaxAge.Duration), time.Time{}, nil
+	}
+	if next.IsZero() || expires.Before(next) {
+		next = expires
+	}
+	return "", next, nil
+}
+
+// issuedAt returns when the console token of the supplied operator in the park
+// was issued, if it has been.
+func issuedAt(po *park.Operator) *metav1.Time {
+	if po.Console == nil {
+		return nil
+	}
+	t := metav1.NewTime(po.Console.IssuedAt)
+	return &t
+}
@@ -0,0 +1,584 @@
+package rideoperator
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	namespaced "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/pkg/park"
+	ridereconciler "github.com/n3wscott/theme-park-provider/pkg/reconciler/ride"
+)
+
+var _ = Describe("RideOperator handler", func() {
+	const (
+		rideName     = "test-ride"
+		operatorName = "test-operator"
+	)
+
+	var (
+		ride *v1alpha1.Ride
+		ro   *v1alpha1.RideOperator
+		ext  managed.TypedExternalClient[resource.Managed]
+	)
+
+	BeforeEach(func() {
+		pr, err := parkClient.CreateRide(ctx, park.Ride{Name: rideName, Type: "rollercoaster", Capacity: 24})
+		Expect(err).NotTo(HaveOccurred())
+
+		ride = &v1alpha1.Ride{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       rideName,
+				Labels:     map[string]string{"themepark.n3wscott.com/area": "frontier"},
+				Finalizers: []string{"test.themepark.n3wscott.com/hold"},
+			},
+			Spec: v1alpha1.RideSpec{
+				ForProvider: v1alpha1.RideParameters{Type: "rollercoaster"},
+			},
+		}
+		meta.SetExternalName(ride, pr.ID)
+		Expect(k8sClient.Create(ctx, ride)).To(Succeed())
+
+		ro = &v1alpha1.RideOperator{
+			ObjectMeta: metav1.ObjectMeta{Name: operatorName},
+			Spec: v1alpha1.RideOperatorSpec{
+				ForProvider: v1alpha1.RideOperatorParameters{
+					Frequency: 10,
+					Ride: &xpv1.TypedReference{
+						APIVersion: v1alpha1.GroupVersion.String(),
+						Kind:       v1alpha1.RideKind,
+						Name:       rideName,
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, ro)).To(Succeed())
+		// The gRPC server supplies fully typed objects, the typed client does
+		// not.
+		ro.SetGroupVersionKind(v1alpha1.RideOperatorGroupVersionKind)
+
+		c := &ConnectorWrapper{Client: k8sClient}
+		ext, err = c.Connect(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, ro))).To(Succeed())
+		if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(ride), ride); err == nil {
+			ride.SetFinalizers(nil)
+			Expect(k8sClient.Update(ctx, ride)).To(Succeed())
+		}
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, ride))).To(Succeed())
+
+		operators, err := parkClient.ListOperators(ctx, "")
+		Expect(err).NotTo(HaveOccurred())
+		for _, o := range operators {
+			Expect(parkClient.DeleteOperator(ctx, o.ID)).To(Succeed())
+		}
+		rides, err := parkClient.ListRides(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		for _, r := range rides {
+			Expect(parkClient.DeleteRide(ctx, r.ID)).To(Succeed())
+		}
+	})
+
+	It("should assign the operator to the ride in the park", func() {
+		_, err := ext.Create(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		po, err := parkClient.GetOperator(ctx, meta.GetExternalName(ro))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(po.RideID).To(Equal(meta.GetExternalName(ride)))
+
+		obs, err := ext.Observe(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceExists).To(BeTrue())
+		Expect(obs.ResourceUpToDate).To(BeTrue())
+		Expect(ro.GetCondition(xpv1.TypeReady).Reason).To(Equal(xpv1.ReasonAvailable))
+		Expect(ro.Status.RideUID).To(Equal(ride.GetUID()))
+	})
+
+	It("should publish the operator's console credentials", func() {
+		cre, err := ext.Create(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		id := meta.GetExternalName(ro)
+		Expect(cre.ConnectionDetails).To(HaveKeyWithValue(xpv1.ResourceCredentialsSecretEndpointKey, []byte(parkServer.URL+"/operators/"+id)))
+		Expect(cre.ConnectionDetails).To(HaveKeyWithValue(xpv1.ResourceCredentialsSecretUserKey, []byte(id)))
+		token := cre.ConnectionDetails[xpv1.ResourceCredentialsSecretTokenKey]
+		Expect(token).NotTo(BeEmpty())
+
+		By("publishing the same credentials when observed")
+		obs, err := ext.Observe(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ConnectionDetails).To(Equal(cre.ConnectionDetails))
+
+		By("authorizing the operator to read their assignment")
+		oc := park.NewClient(parkServer.URL, park.WithBearerToken(string(token)))
+		po, err := oc.GetOperator(ctx, id)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(po.RideID).To(Equal(meta.GetExternalName(ride)))
+	})
+
+	It("should report a Ride that does not exist", func() {
+		ro.Spec.ForProvider.Ride.Name = "phantom-ride"
+
+		_, err := ext.Create(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		obs, err := ext.Observe(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceExists).To(BeTrue())
+
+		cond := ro.GetCondition(xpv1.TypeReady)
+		Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(xpv1.ConditionReason("RideNotFound")))
+		Expect(ro.Status.RideUID).To(BeEmpty())
+	})
+
+	It("should report a Ride that is being deleted", func() {
+		_, err := ext.Create(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Delete(ctx, ride)).To(Succeed())
+
+		_, err = ext.Observe(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+
+		cond := ro.GetCondition(xpv1.TypeReady)
+		Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(xpv1.ConditionReason("RideDeleting")))
+		Expect(ro.Status.RideUID).To(Equal(ride.GetUID()))
+	})
+	It("should become Unassigned when its Ride disappears", func() {
+		_, err := ext.Create(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ext.Observe(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ro.Status.RideUID).To(Equal(ride.GetUID()))
+
+		By("deleting the Ride")
+		Expect(parkClient.DeleteRide(ctx, meta.GetExternalName(ride))).To(Succeed())
+		ride.SetFinalizers(nil)
+		Expect(k8sClient.Update(ctx, ride)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, ride)).To(Succeed())
+
+		for range 2 {
+			obs, err := ext.Observe(ctx, ro)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(obs.ResourceUpToDate).To(BeTrue())
+			cond := ro.GetCondition(xpv1.TypeReady)
+			Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+			Expect(cond.Reason).To(Equal(ReasonUnassigned))
+			Expect(ro.Status.RideUID).To(BeEmpty())
+			Expect(ro.Status.Available).To(BeTrue())
+		}
+	})
+
+	It("should resolve its Ride by selector", func() {
+		ro.Spec.ForProvider.Ride = nil
+		ro.Spec.ForProvider.RideSelector = &xpv1.Selector{
+			MatchLabels: map[string]string{"themepark.n3wscott.com/area": "frontier"},
+		}
+
+		Expect(ro.ResolveReferences(ctx, k8sClient)).To(Succeed())
+		Expect(ro.Spec.ForProvider.RideRef).NotTo(BeNil())
+		Expect(ro.Spec.ForProvider.RideRef.Name).To(Equal(rideName))
+		Expect(ro.Spec.ForProvider.Ride).NotTo(BeNil())
+		Expect(ro.Spec.ForProvider.Ride.Kind).To(Equal(v1alpha1.RideKind))
+		Expect(ro.Spec.ForProvider.Ride.Name).To(Equal(rideName))
+	})
+
+	It("should resolve its Ride by reference", func() {
+		ro.Spec.ForProvider.Ride = nil
+		ro.Spec.ForProvider.RideRef = &xpv1.Reference{Name: rideName}
+
+		Expect(ro.ResolveReferences(ctx, k8sClient)).To(Succeed())
+		Expect(ro.Spec.ForProvider.Ride).NotTo(BeNil())
+		Expect(ro.Spec.ForProvider.Ride.Name).To(Equal(rideName))
+	})
+
+	It("should fail to resolve a reference to a Ride that does not exist", func() {
+		ro.Spec.ForProvider.Ride = nil
+		ro.Spec.ForProvider.RideRef = &xpv1.Reference{Name: "phantom-ride"}
+
+		Expect(ro.ResolveReferences(ctx, k8sClient)).NotTo(Succeed())
+	})
+
+	DescribeTable("should reject an invalid operator",
+		func(frequency int, ride xpv1.TypedReference, message string) {
+			o := &v1alpha1.RideOperator{
+				ObjectMeta: metav1.ObjectMeta{Name: "invalid-operator"},
+				Spec: v1alpha1.RideOperatorSpec{
+					ForProvider: v1alpha1.RideOperatorParameters{
+						Frequency: frequency,
+						Ride:      &ride,
+					},
+				},
+			}
+			err := k8sClient.Create(ctx, o)
+			Expect(kerrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("zero frequency", 0, xpv1.TypedReference{
+			APIVersion: v1alpha1.GroupVersion.String(), Kind: v1alpha1.RideKind, Name: rideName,
+		}, "frequency must be positive"),
+		Entry("frequency too high", 61, xpv1.TypedReference{
+			APIVersion: v1alpha1.GroupVersion.String(), Kind: v1alpha1.RideKind, Name: rideName,
+		}, "frequency must be at most 60 per hour"),
+		Entry("ride of another kind", 10, xpv1.TypedReference{
+			APIVersion: v1alpha1.GroupVersion.String(), Kind: "Pod", Name: rideName,
+		}, "ride must reference a Ride"),
+		Entry("ride in another API group", 10, xpv1.TypedReference{
+			APIVersion: "v1", Kind: v1alpha1.RideKind, Name: rideName,
+		}, "ride must reference the themepark.n3wscott.com API group"),
+	)
+
+	It("should default an unset frequency", func() {
+		o := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": v1alpha1.GroupVersion.String(),
+			"kind":       v1alpha1.RideOperatorKind,
+			"metadata":   map[string]any{"name": "default-operator"},
+			"spec": map[string]any{"forProvider": map[string]any{
+				"ride": map[string]any{"apiVersion": v1alpha1.GroupVersion.String(), "kind": v1alpha1.RideKind, "name": rideName},
+			}},
+		}}
+		Expect(k8sClient.Create(ctx, o)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, o)).To(Succeed()) })
+
+		got := &v1alpha1.RideOperator{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(o), got)).To(Succeed())
+		Expect(got.Spec.ForProvider.Frequency).To(Equal(4))
+	})
+
+	It("should report whether the operator is on shift", func() {
+		_, err := ext.Create(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = ext.Observe(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ro.Status.OnShift).To(BeTrue())
+
+		tomorrow := v1alpha1.Weekday(time.Now().UTC().AddDate(0, 0, 1).Weekday().String())
+		ro.Spec.ForProvider.Shifts = []v1alpha1.WeeklyWindow{{Days: []v1alpha1.Weekday{tomorrow}, Start: "12:00", End: "13:00"}}
+		_, err = ext.Observe(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ro.Status.OnShift).To(BeFalse())
+
+		ro.Spec.ForProvider.TimeZone = "Mars/Olympus_Mons"
+		_, err = ext.Observe(ctx, ro)
+		Expect(err).To(MatchError(ContainSubstring("invalid shifts")))
+	})
+
+	It("should report whether the operator is available for reassignment", func() {
+		_, err := ext.Create(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("operating a ride")
+		_, err = ext.Observe(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ro.Status.Available).To(BeFalse())
+
+		By("putting the ride under maintenance")
+		ride.SetConditions(ridereconciler.UnderMaintenance())
+		Expect(k8sClient.Status().Update(ctx, ride)).To(Succeed())
+		_, err = ext.Observe(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ro.Status.Available).To(BeTrue())
+
+		By("going off shift")
+		tomorrow := v1alpha1.Weekday(time.Now().UTC().AddDate(0, 0, 1).Weekday().String())
+		ro.Spec.ForProvider.Shifts = []v1alpha1.WeeklyWindow{{Days: []v1alpha1.Weekday{tomorrow}, Start: "12:00", End: "13:00"}}
+		_, err = ext.Observe(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ro.Status.Available).To(BeFalse())
+
+		By("being unassigned")
+		ro.Spec.ForProvider.Shifts = nil
+		ro.Spec.ForProvider.Ride = nil
+		_, err = ext.Observe(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ro.Status.Available).To(BeTrue())
+	})
+
+	It("should rotate the console token when it is older than its maximum age", func() {
+		ro.Spec.ForProvider.CredentialRotation = &v1alpha1.CredentialRotationPolicy{
+			MaxAge: &metav1.Duration{Duration: time.Second},
+		}
+		cre, err := ext.Create(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		old := cre.ConnectionDetails[xpv1.ResourceCredentialsSecretTokenKey]
+		issued := ro.Status.CredentialsIssuedAt
+		Expect(issued).NotTo(BeNil())
+
+		By("waiting for the token to age")
+		Eventually(func() string {
+			obs, err := ext.Observe(ctx, ro)
+			Expect(err).NotTo(HaveOccurred())
+			return obs.Diff
+		}).WithTimeout(5 * time.Second).Should(ContainSubstring("credentials: console token is older than 1s"))
+
+		By("issuing a new token and updating the connection secret in place")
+		upd, err := ext.Update(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		token := upd.ConnectionDetails[xpv1.ResourceCredentialsSecretTokenKey]
+		Expect(token).NotTo(BeEmpty())
+		Expect(token).NotTo(Equal(old))
+		Expect(ro.Status.CredentialsIssuedAt.After(issued.Time)).To(BeTrue())
+
+		By("keeping the previous token valid for the default overlap")
+		id := meta.GetExternalName(ro)
+		for _, t := range [][]byte{old, token} {
+			_, err := park.NewClient(parkServer.URL, park.WithBearerToken(string(t))).GetOperator(ctx, id)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	It("should rotate the console token when requested", func() {
+		ro.Spec.ForProvider.CredentialRotation = &v1alpha1.CredentialRotationPolicy{
+			Overlap: &metav1.Duration{Duration: 0},
+		}
+		cre, err := ext.Create(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		old := cre.ConnectionDetails[xpv1.ResourceCredentialsSecretTokenKey]
+
+		By("not rotating before the requested time")
+		requested := time.Now().Add(time.Second).UTC().Format(time.RFC3339)
+		meta.AddAnnotations(ro, map[string]string{AnnotationKeyRotateCredentials: requested})
+		obs, err := ext.Observe(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceUpToDate).To(BeTrue())
+
+		By("rotating once the requested time has passed")
+		Eventually(func() string {
+			obs, err := ext.Observe(ctx, ro)
+			Expect(err).NotTo(HaveOccurred())
+			return obs.Diff
+		}).WithTimeout(5 * time.Second).Should(ContainSubstring("credentials: rotation requested at " + requested))
+		upd, err := ext.Update(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(upd.ConnectionDetails[xpv1.ResourceCredentialsSecretTokenKey]).NotTo(Equal(old))
+
+		By("invalidating the previous token immediately without overlap")
+		_, err = park.NewClient(parkServer.URL, park.WithBearerToken(string(old))).GetOperator(ctx, meta.GetExternalName(ro))
+		Expect(err).To(HaveOccurred())
+
+		By("only rotating once per request")
+		obs, err = ext.Observe(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obs.ResourceUpToDate).To(BeTrue())
+
+		By("rejecting a request that isn't a time")
+		meta.AddAnnotations(ro, map[string]string{AnnotationKeyRotateCredentials: "now please"})
+		_, err = ext.Observe(ctx, ro)
+		Expect(err).To(MatchError(ContainSubstring(AnnotationKeyRotateCredentials)))
+	})
+
+	DescribeTable("should only do what its management policies allow",
+		func(policies xpv1.ManagementPolicies, create, update, del bool) {
+			ro.SetManagementPolicies(policies)
+			ro.Spec.ForProvider.CredentialRotation = &v1alpha1.CredentialRotationPolicy{
+				MaxAge: &metav1.Duration{Duration: 0},
+			}
+
+			By("creating an operator")
+			_, cerr := ext.Create(ctx, ro.DeepCopy())
+			operators, err := parkClient.ListOperators(ctx, "")
+			Expect(err).NotTo(HaveOccurred())
+			if create {
+				Expect(cerr).NotTo(HaveOccurred())
+				Expect(operators).To(HaveLen(1))
+			} else {
+				Expect(cerr).To(MatchError(ContainSubstring("do not allow Create")))
+				Expect(operators).To(BeEmpty())
+			}
+
+			By("importing an existing operator")
+			po, err := parkClient.CreateOperator(ctx, park.Operator{Name: "existing", RideID: meta.GetExternalName(ride), Frequency: 5})
+			Expect(err).NotTo(HaveOccurred())
+			meta.SetExternalName(ro, po.ID)
+
+			obs, err := ext.Observe(ctx, ro)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(obs.ResourceExists).To(BeTrue())
+			Expect(obs.Diff).To(ContainSubstring("frequency: 5 -> 10"))
+			if update {
+				Expect(obs.Diff).To(ContainSubstring("credentials: console token is older than 0s"))
+			} else {
+				Expect(obs.Diff).NotTo(ContainSubstring("credentials"))
+			}
+
+			By("updating the operator")
+			_, err = ext.Update(ctx, ro)
+			Expect(err).NotTo(HaveOccurred())
+			got, err := parkClient.GetOperator(ctx, po.ID)
+			Expect(err).NotTo(HaveOccurred())
+			if update {
+				Expect(got.Frequency).To(Equal(10))
+				Expect(got.Console.Token).NotTo(Equal(po.Console.Token))
+			} else {
+				Expect(got).To(Equal(po))
+			}
+
+			By("deleting the operator")
+			_, err = ext.Delete(ctx, ro)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = parkClient.GetOperator(ctx, po.ID)
+			if del {
+				Expect(park.IsNotFound(err)).To(BeTrue())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("all actions", xpv1.ManagementPolicies{xpv1.ManagementActionAll}, true, true, true),
+		Entry("Observe only", xpv1.ManagementPolicies{xpv1.ManagementActionObserve}, false, false, false),
+		Entry("all but Create", xpv1.ManagementPolicies{
+			xpv1.ManagementActionObserve, xpv1.ManagementActionUpdate, xpv1.ManagementActionDelete, xpv1.ManagementActionLateInitialize,
+		}, false, true, true),
+		Entry("all but Update", xpv1.ManagementPolicies{
+			xpv1.ManagementActionObserve, xpv1.ManagementActionCreate, xpv1.ManagementActionDelete, xpv1.ManagementActionLateInitialize,
+		}, true, false, true),
+		Entry("all but Delete", xpv1.ManagementPolicies{
+			xpv1.ManagementActionObserve, xpv1.ManagementActionCreate, xpv1.ManagementActionUpdate, xpv1.ManagementActionLateInitialize,
+		}, true, true, false),
+	)
+})
+
+var _ = Describe("Namespaced RideOperator handler", func() {
+	const (
+		rideName       = "test-ride"
+		operatorName   = "test-operator"
+		namespace      = "team-a"
+		otherNamespace = "team-b"
+	)
+
+	var (
+		ride *namespaced.Ride
+		c    *ConnectorWrapper
+	)
+
+	BeforeEach(func() {
+		createNamespace(namespace)
+		createNamespace(otherNamespace)
+
+		pr, err := parkClient.CreateRide(ctx, park.Ride{Name: rideName, Type: "rollercoaster", Capacity: 24})
+		Expect(err).NotTo(HaveOccurred())
+
+		ride = &namespaced.Ride{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      rideName,
+				Labels:    map[string]string{"themepark.n3wscott.com/area": "frontier"},
+			},
+			Spec: namespaced.RideSpec{
+				ForProvider: namespaced.RideParameters{Type: "rollercoaster"},
+			},
+		}
+		meta.SetExternalName(ride, pr.ID)
+		Expect(k8sClient.Create(ctx, ride)).To(Succeed())
+
+		c = &ConnectorWrapper{Client: k8sClient}
+	})
+
+	AfterEach(func() {
+		for _, ns := range []string{namespace, otherNamespace} {
+			Expect(k8sClient.DeleteAllOf(ctx, &namespaced.RideOperator{}, client.InNamespace(ns))).To(Succeed())
+			Expect(k8sClient.DeleteAllOf(ctx, &namespaced.Ride{}, client.InNamespace(ns))).To(Succeed())
+		}
+
+		operators, err := parkClient.ListOperators(ctx, "")
+		Expect(err).NotTo(HaveOccurred())
+		for _, o := range operators {
+			Expect(parkClient.DeleteOperator(ctx, o.ID)).To(Succeed())
+		}
+		rides, err := parkClient.ListRides(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		for _, r := range rides {
+			Expect(parkClient.DeleteRide(ctx, r.ID)).To(Succeed())
+		}
+	})
+
+	// newOperator creates a namespaced RideOperator assigned to the test Ride,
+	// and connects to the provider for it.
+	newOperator := func(ns string) (*namespaced.RideOperator, managed.TypedExternalClient[resource.Managed]) {
+		ro := &namespaced.RideOperator{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: operatorName},
+			Spec: namespaced.RideOperatorSpec{
+				ForProvider: namespaced.RideOperatorParameters{
+					Frequency: 10,
+					Ride: &xpv1.TypedReference{
+						APIVersion: namespaced.GroupVersion.String(),
+						Kind:       namespaced.RideKind,
+						Name:       rideName,
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, ro)).To(Succeed())
+		// The gRPC server supplies fully typed objects, the typed client does
+		// not.
+		ro.SetGroupVersionKind(namespaced.RideOperatorGroupVersionKind)
+
+		ext, err := c.Connect(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		return ro, ext
+	}
+
+	It("should assign the operator to the Ride in its namespace", func() {
+		ro, ext := newOperator(namespace)
+
+		_, err := ext.Create(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		po, err := parkClient.GetOperator(ctx, meta.GetExternalName(ro))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(po.RideID).To(Equal(meta.GetExternalName(ride)))
+
+		_, err = ext.Observe(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ro.GetCondition(xpv1.TypeReady).Reason).To(Equal(xpv1.ReasonAvailable))
+		Expect(ro.Status.RideUID).To(Equal(ride.GetUID()))
+	})
+
+	It("should not assign the operator to a Ride in another namespace", func() {
+		ro, ext := newOperator(otherNamespace)
+
+		_, err := ext.Create(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		po, err := parkClient.GetOperator(ctx, meta.GetExternalName(ro))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(po.RideID).To(BeEmpty())
+
+		_, err = ext.Observe(ctx, ro)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ro.GetCondition(xpv1.TypeReady).Reason).To(Equal(xpv1.ConditionReason("RideNotFound")))
+		Expect(ro.Status.RideUID).To(BeEmpty())
+	})
+
+	It("should only resolve its Ride in its namespace", func() {
+		ro, _ := newOperator(namespace)
+		ro.Spec.ForProvider.Ride = nil
+		ro.Spec.ForProvider.RideSelector = &xpv1.Selector{
+			MatchLabels: map[string]string{"themepark.n3wscott.com/area": "frontier"},
+		}
+		Expect(ro.ResolveReferences(ctx, k8sClient)).To(Succeed())
+		Expect(ro.Spec.ForProvider.Ride).To(Equal(&xpv1.TypedReference{
+			APIVersion: namespaced.GroupVersion.String(),
+			Kind:       namespaced.RideKind,
+			Name:       rideName,
+		}))
+
+		other, _ := newOperator(otherNamespace)
+		other.Spec.ForProvider.Ride = nil
+		other.Spec.ForProvider.RideRef = &xpv1.Reference{Name: rideName}
+		Expect(other.ResolveReferences(ctx, k8sClient)).NotTo(Succeed())
+	})
+})
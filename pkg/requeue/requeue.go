@@ -0,0 +1,101 @@
+// Package requeue triggers reconciles of managed resources at a set time.
+//
+// Handlers only run when the reconciler asks them to, which is at least once
+// per poll interval. Some state, like whether an operator is on shift, changes
+// at a known time. Rather than wait for the next poll a handler can ask for a
+// reconcile at that time. The reconciler watches managed resources for
+// annotation changes, so a Scheduler requests a reconcile by annotating the
+// resource.
+package requeue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/n3wscott/theme-park-provider/pkg/reconciler/policy"
+)
+
+// AnnotationKeyRequeuedAt is set to the time a Scheduler requested a reconcile
+// of a resource.
+const AnnotationKeyRequeuedAt = "themepark.n3wscott.com/requeued-at"
+
+// A Scheduler requests reconciles of resources at a set time. A nil Scheduler
+// never requests a reconcile.
+type Scheduler struct {
+	kube client.Client
+	log  logging.Logger
+
+	mu     sync.Mutex
+	timers map[types.UID]timer
+}
+
+type timer struct {
+	at    time.Time
+	timer *time.Timer
+}
+
+// NewScheduler returns a Scheduler that annotates resources using the supplied
+// client.
+func NewScheduler(kube client.Client, log logging.Logger) *Scheduler {
+	return &Scheduler{kube: kube, log: log, timers: map[types.UID]timer{}}
+}
+
+// At requests a reconcile of the supplied resource at the supplied time,
+// replacing any reconcile previously requested for it. A zero time cancels the
+// previously requested reconcile. No reconcile is requested for a managed
+// resource whose management policies don't allow it to be observed, because
+// reconciling it would do nothing.
+func (s *Scheduler) At(o client.Object, at time.Time) {
+	if s == nil {
+		return
+	}
+	if mg, ok := o.(resource.Managed); ok && !policy.Allows(mg, xpv1.ManagementActionObserve) {
+		at = time.Time{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	uid := o.GetUID()
+	if t, ok := s.timers[uid]; ok {
+		if t.at.Equal(at) {
+			return
+		}
+		t.timer.Stop()
+		delete(s.timers, uid)
+	}
+	if at.IsZero() {
+		return
+	}
+
+	// Copy the resource, the caller is free to modify theirs.
+	cp := o.DeepCopyObject().(client.Object) //nolint:forcetypeassert // A copy of a client.Object is a client.Object.
+	s.timers[uid] = timer{at: at, timer: time.AfterFunc(time.Until(at), func() { s.requeue(cp, at) })}
+}
+
+func (s *Scheduler) requeue(o client.Object, at time.Time) {
+	s.mu.Lock()
+	if t, ok := s.timers[o.GetUID()]; ok && t.at.Equal(at) {
+		delete(s.timers, o.GetUID())
+	}
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// The annotation must change to trigger a reconcile, even when two are
+	// requested within the same second.
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, AnnotationKeyRequeuedAt, time.Now().UTC().Format(time.RFC3339Nano))
+	if err := s.kube.Patch(ctx, o, client.RawPatch(types.MergePatchType, []byte(patch))); client.IgnoreNotFound(err) != nil {
+		s.log.Info("Cannot request reconcile", "name", o.GetName(), "error", err)
+	}
+}
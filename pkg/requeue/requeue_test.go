@@ -0,0 +1,95 @@
+package requeue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+)
+
+func TestAt(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	r := &v1alpha1.Ride{ObjectMeta: metav1.ObjectMeta{Name: "coaster", UID: "coaster-uid"}}
+	kube := fake.NewClientBuilder().WithScheme(s).WithObjects(r).Build()
+
+	sch := NewScheduler(kube, logging.NewNopLogger())
+
+	// Only the most recently requested reconcile should happen.
+	sch.At(r, time.Now().Add(time.Hour))
+	sch.At(r, time.Now().Add(10*time.Millisecond))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		got := &v1alpha1.Ride{}
+		if err := kube.Get(context.Background(), client.ObjectKeyFromObject(r), got); err != nil {
+			t.Fatal(err)
+		}
+		if got.GetAnnotations()[AnnotationKeyRequeuedAt] != "" {
+			sch.mu.Lock()
+			defer sch.mu.Unlock()
+			if len(sch.timers) != 0 {
+				t.Errorf("At(...): want no pending reconciles, got %d", len(sch.timers))
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("At(...): want %s annotation", AnnotationKeyRequeuedAt)
+}
+
+func TestAtNotObserved(t *testing.T) {
+	r := &v1alpha1.Ride{ObjectMeta: metav1.ObjectMeta{Name: "coaster", UID: "coaster-uid"}}
+	sch := NewScheduler(fake.NewClientBuilder().Build(), logging.NewNopLogger())
+
+	sch.At(r, time.Now().Add(time.Hour))
+	r.SetManagementPolicies(xpv1.ManagementPolicies{xpv1.ManagementActionCreate, xpv1.ManagementActionDelete})
+	sch.At(r, time.Now().Add(time.Hour))
+
+	sch.mu.Lock()
+	defer sch.mu.Unlock()
+	if len(sch.timers) != 0 {
+		t.Errorf("At(...): want no pending reconciles of a Ride that can't be observed, got %d", len(sch.timers))
+	}
+}
+
+func TestRequeueWithinASecond(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	r := &v1alpha1.Ride{ObjectMeta: metav1.ObjectMeta{Name: "coaster", UID: "coaster-uid"}}
+	kube := fake.NewClientBuilder().WithScheme(s).WithObjects(r).Build()
+	sch := NewScheduler(kube, logging.NewNopLogger())
+
+	// Each reconcile must change the annotation, or it won't be triggered.
+	seen := map[string]bool{}
+	for range 3 {
+		sch.requeue(r, time.Now())
+		got := &v1alpha1.Ride{}
+		if err := kube.Get(context.Background(), client.ObjectKeyFromObject(r), got); err != nil {
+			t.Fatal(err)
+		}
+		at := got.GetAnnotations()[AnnotationKeyRequeuedAt]
+		if seen[at] {
+			t.Fatalf("requeue(...): want a new %s annotation, got %s again", AnnotationKeyRequeuedAt, at)
+		}
+		seen[at] = true
+	}
+}
+
+func TestAtNil(t *testing.T) {
+	var s *Scheduler
+	s.At(&v1alpha1.Ride{}, time.Now())
+}
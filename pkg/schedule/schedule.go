@@ -0,0 +1,233 @@
+// Package schedule evaluates windows of time that recur every week, such as
+// operator shifts and park opening hours.
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+)
+
+// A Schedule is a set of windows of time that recur every week in a time zone.
+type Schedule struct {
+	loc     *time.Location
+	windows []window
+}
+
+// A window starts at start minutes past midnight on each of its days, and ends
+// at end minutes past midnight. A window that ends before it starts runs past
+// midnight into the next day. A window that ends when it starts lasts all day.
+type window struct {
+	days       [7]bool
+	start, end int
+}
+
+// Weekly returns a Schedule of the supplied windows in the supplied IANA time
+// zone, e.g. America/Los_Angeles. The time zone defaults to UTC.
+func Weekly(timeZone string, windows []v1alpha1.WeeklyWindow) (*Schedule, error) {
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid time zone %q", timeZone)
+	}
+
+	s := &Schedule{loc: loc, windows: make([]window, 0, len(windows))}
+	for _, ww := range windows {
+		w, err := parse(ww)
+		if err != nil {
+			return nil, err
+		}
+		s.windows = append(s.windows, w)
+	}
+	return s, nil
+}
+
+// Contains returns true if the supplied time falls within any window of the
+// Schedule.
+func (s *Schedule) Contains(t time.Time) bool {
+	t = t.In(s.loc)
+	for _, w := range s.windows {
+		// A window that started yesterday may still be running.
+		for _, day := range []int{-1, 0} {
+			start, end, ok := w.on(t, day, s.loc)
+			if ok && !t.Before(start) && t.Before(end) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Next returns the first time after the supplied time at which a window of the
+// Schedule starts or ends, or the zero time if it has no windows.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.In(s.loc)
+	var next time.Time
+	for _, w := range s.windows {
+		for day := -1; day <= 7; day++ {
+			start, end, ok := w.on(t, day, s.loc)
+			if !ok {
+				continue
+			}
+			for _, b := range []time.Time{start, end} {
+				if b.After(t) && (next.IsZero() || b.Before(next)) {
+					next = b
+				}
+			}
+		}
+	}
+	return next
+}
+
+// on returns when the window starts and ends on the day the supplied number of
+// days after the supplied time, if it runs on that day.
+func (w window) on(t time.Time, days int, loc *time.Location) (time.Time, time.Time, bool) {
+	y, m, d := t.Date()
+	midnight := time.Date(y, m, d+days, 0, 0, 0, 0, loc)
+	if !w.days[midnight.Weekday()] {
+		return time.Time{}, time.Time{}, false
+	}
+
+	start := time.Date(y, m, d+days, 0, w.start, 0, 0, loc)
+	end := time.Date(y, m, d+days, 0, w.end, 0, 0, loc)
+	if w.end <= w.start {
+		end = time.Date(y, m, d+days+1, 0, w.end, 0, 0, loc)
+	}
+	return start, end, true
+}
+
+var weekdays = map[string]time.Weekday{}
+
+func init() {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		weekdays[strings.ToLower(d.String())] = d
+	}
+}
+
+func parse(ww v1alpha1.WeeklyWindow) (window, error) {
+	w := window{}
+	if len(ww.Days) == 0 {
+		for d := range w.days {
+			w.days[d] = true
+		}
+	}
+	for _, name := range ww.Days {
+		d, ok := weekdays[strings.ToLower(string(name))]
+		if !ok {
+			return window{}, errors.Errorf("invalid day %q", name)
+		}
+		w.days[d] = true
+	}
+
+	var err error
+	if w.start, err = minutes(ww.Start); err != nil {
+		return window{}, errors.Wrap(err, "invalid start")
+	}
+	if w.end, err = minutes(ww.End); err != nil {
+		return window{}, errors.Wrap(err, "invalid end")
+	}
+	return w, nil
+}
+
+// minutes returns the minutes past midnight of the supplied HH:MM time of day.
+func minutes(hhmm string) (int, error) {
+	var h, m int
+	if _, err := fmt.Sscanf(hhmm, "%d:%d", &h, &m); err != nil || h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, errors.Errorf("%q is not a time of day in HH:MM format", hhmm)
+	}
+	return h*60 + m, nil
+}
+
+// OnShift returns true if the supplied RideOperator is on shift at the supplied
+// time, and the time at which that next changes. An operator without shifts is
+// always on shift, so that never changes.
+func OnShift(ro *v1alpha1.RideOperator, t time.Time) (bool, time.Time, error) {
+	if len(ro.Spec.ForProvider.Shifts) == 0 {
+		return true, time.Time{}, nil
+	}
+	s, err := Weekly(ro.Spec.ForProvider.TimeZone, ro.Spec.ForProvider.Shifts)
+	if err != nil {
+		return false, time.Time{}, errors.Wrap(err, "invalid shifts")
+	}
+	return s.Contains(t), s.Next(t), nil
+}
+
+// ParkOpen returns true if the supplied Park is open at the supplied time, and
+// the time at which that may next change. A special event opens the park even
+// on a closure date, and a park without opening hours is open all day on every
+// other date.
+func ParkOpen(p *v1alpha1.Park, t time.Time) (bool, time.Time, error) {
+	fp := p.Spec.ForProvider
+	hours, err := Weekly(fp.TimeZone, fp.OpeningHours)
+	if err != nil {
+		return false, time.Time{}, errors.Wrap(err, "invalid opening hours")
+	}
+	t = t.In(hours.loc)
+
+	open := len(fp.OpeningHours) == 0 || hours.Contains(t)
+	next := hours.Next(t)
+	earliest := func(b time.Time) {
+		if b.After(t) && (next.IsZero() || b.Before(next)) {
+			next = b
+		}
+	}
+
+	for _, cd := range fp.ClosureDates {
+		day, err := time.ParseInLocation(time.DateOnly, string(cd), hours.loc)
+		if err != nil {
+			return false, time.Time{}, errors.Wrapf(err, "invalid closure date %q", cd)
+		}
+		end := day.AddDate(0, 0, 1)
+		if !t.Before(day) && t.Before(end) {
+			open = false
+		}
+		earliest(day)
+		earliest(end)
+	}
+
+	for _, e := range fp.SpecialEvents {
+		if !t.Before(e.Start.Time) && t.Before(e.End.Time) {
+			open = true
+		}
+		earliest(e.Start.Time)
+		earliest(e.End.Time)
+	}
+
+	return open, next, nil
+}
+
+// UnderMaintenance returns true if the supplied time falls within any of the
+// supplied maintenance schedules, and the time at which that may next change.
+func UnderMaintenance(schedules []v1alpha1.MaintenanceSchedule, t time.Time) (bool, time.Time, error) {
+	under := false
+	var next time.Time
+	earliest := func(b time.Time) {
+		if b.After(t) && (next.IsZero() || b.Before(next)) {
+			next = b
+		}
+	}
+
+	for _, ms := range schedules {
+		s, err := Weekly(ms.TimeZone, ms.Recurring)
+		if err != nil {
+			return false, time.Time{}, errors.Wrap(err, "invalid maintenance schedule")
+		}
+		if s.Contains(t) {
+			under = true
+		}
+		earliest(s.Next(t))
+
+		for _, w := range ms.OneOff {
+			if !t.Before(w.Start.Time) && t.Before(w.End.Time) {
+				under = true
+			}
+			earliest(w.Start.Time)
+			earliest(w.End.Time)
+		}
+	}
+
+	return under, next, nil
+}
@@ -0,0 +1,296 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+)
+
+func TestContains(t *testing.T) {
+	// 2025-06-02 is a Monday.
+	cases := map[string]struct {
+		windows []v1alpha1.WeeklyWindow
+		at      time.Time
+		want    bool
+	}{
+		"DuringWindow": {
+			windows: []v1alpha1.WeeklyWindow{{Days: []v1alpha1.Weekday{"Monday"}, Start: "09:00", End: "17:00"}},
+			at:      time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC),
+			want:    true,
+		},
+		"AtStart": {
+			windows: []v1alpha1.WeeklyWindow{{Days: []v1alpha1.Weekday{"Monday"}, Start: "09:00", End: "17:00"}},
+			at:      time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC),
+			want:    true,
+		},
+		"AtEnd": {
+			windows: []v1alpha1.WeeklyWindow{{Days: []v1alpha1.Weekday{"Monday"}, Start: "09:00", End: "17:00"}},
+			at:      time.Date(2025, 6, 2, 17, 0, 0, 0, time.UTC),
+			want:    false,
+		},
+		"OtherDay": {
+			windows: []v1alpha1.WeeklyWindow{{Days: []v1alpha1.Weekday{"Tuesday"}, Start: "09:00", End: "17:00"}},
+			at:      time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC),
+			want:    false,
+		},
+		"EveryDay": {
+			windows: []v1alpha1.WeeklyWindow{{Start: "09:00", End: "17:00"}},
+			at:      time.Date(2025, 6, 7, 12, 0, 0, 0, time.UTC),
+			want:    true,
+		},
+		"PastMidnight": {
+			windows: []v1alpha1.WeeklyWindow{{Days: []v1alpha1.Weekday{"Sunday"}, Start: "22:00", End: "02:00"}},
+			at:      time.Date(2025, 6, 2, 1, 0, 0, 0, time.UTC),
+			want:    true,
+		},
+		"AllDay": {
+			windows: []v1alpha1.WeeklyWindow{{Days: []v1alpha1.Weekday{"Monday"}, Start: "06:00", End: "06:00"}},
+			at:      time.Date(2025, 6, 3, 5, 59, 0, 0, time.UTC),
+			want:    true,
+		},
+		"NoWindows": {
+			at:   time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC),
+			want: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s, err := Weekly("UTC", tc.windows)
+			if err != nil {
+				t.Fatalf("Weekly(...): %v", err)
+			}
+			if got := s.Contains(tc.at); got != tc.want {
+				t.Errorf("Contains(%s): want %t, got %t", tc.at, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestTimeZone(t *testing.T) {
+	s, err := Weekly("America/Los_Angeles", []v1alpha1.WeeklyWindow{{Start: "09:00", End: "17:00"}})
+	if err != nil {
+		t.Fatalf("Weekly(...): %v", err)
+	}
+	// 09:30 in Los Angeles is 16:30 UTC during daylight saving time.
+	if !s.Contains(time.Date(2025, 6, 2, 16, 30, 0, 0, time.UTC)) {
+		t.Errorf("Contains(...): want the window to be in the Schedule's time zone")
+	}
+	if s.Contains(time.Date(2025, 6, 2, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("Contains(...): want the window to be in the Schedule's time zone")
+	}
+}
+
+func TestNext(t *testing.T) {
+	s, err := Weekly("UTC", []v1alpha1.WeeklyWindow{
+		{Days: []v1alpha1.Weekday{"Monday"}, Start: "09:00", End: "17:00"},
+		{Days: []v1alpha1.Weekday{"Friday"}, Start: "22:00", End: "02:00"},
+	})
+	if err != nil {
+		t.Fatalf("Weekly(...): %v", err)
+	}
+
+	cases := map[string]struct {
+		at   time.Time
+		want time.Time
+	}{
+		"BeforeStart": {
+			at:   time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC),
+			want: time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC),
+		},
+		"DuringWindow": {
+			at:   time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2025, 6, 2, 17, 0, 0, 0, time.UTC),
+		},
+		"AtEnd": {
+			at:   time.Date(2025, 6, 2, 17, 0, 0, 0, time.UTC),
+			want: time.Date(2025, 6, 6, 22, 0, 0, 0, time.UTC),
+		},
+		"PastMidnight": {
+			at:   time.Date(2025, 6, 7, 1, 0, 0, 0, time.UTC),
+			want: time.Date(2025, 6, 7, 2, 0, 0, 0, time.UTC),
+		},
+		"NextWeek": {
+			at:   time.Date(2025, 6, 7, 3, 0, 0, 0, time.UTC),
+			want: time.Date(2025, 6, 9, 9, 0, 0, 0, time.UTC),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := s.Next(tc.at); !got.Equal(tc.want) {
+				t.Errorf("Next(%s): want %s, got %s", tc.at, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	cases := map[string]struct {
+		timeZone string
+		windows  []v1alpha1.WeeklyWindow
+	}{
+		"TimeZone": {timeZone: "Mars/Olympus_Mons"},
+		"Day":      {windows: []v1alpha1.WeeklyWindow{{Days: []v1alpha1.Weekday{"Caturday"}, Start: "09:00", End: "17:00"}}},
+		"Start":    {windows: []v1alpha1.WeeklyWindow{{Start: "25:00", End: "17:00"}}},
+		"End":      {windows: []v1alpha1.WeeklyWindow{{Start: "09:00", End: "5pm"}}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Weekly(tc.timeZone, tc.windows); err == nil {
+				t.Errorf("Weekly(...): want error")
+			}
+		})
+	}
+}
+
+func TestParkOpen(t *testing.T) {
+	park := func(fp v1alpha1.ParkParameters) *v1alpha1.Park {
+		return &v1alpha1.Park{Spec: v1alpha1.ParkSpec{ForProvider: fp}}
+	}
+	hours := []v1alpha1.WeeklyWindow{{Start: "09:00", End: "17:00"}}
+	halloween := v1alpha1.SpecialEvent{
+		Name:  "Halloween Nights",
+		Start: metav1.NewTime(time.Date(2025, 10, 31, 20, 0, 0, 0, time.UTC)),
+		End:   metav1.NewTime(time.Date(2025, 11, 1, 2, 0, 0, 0, time.UTC)),
+	}
+
+	cases := map[string]struct {
+		park     *v1alpha1.Park
+		at       time.Time
+		want     bool
+		wantNext time.Time
+	}{
+		"AlwaysOpen": {
+			park: park(v1alpha1.ParkParameters{}),
+			at:   time.Date(2025, 6, 2, 3, 0, 0, 0, time.UTC),
+			want: true,
+		},
+		"DuringOpeningHours": {
+			park:     park(v1alpha1.ParkParameters{OpeningHours: hours}),
+			at:       time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC),
+			want:     true,
+			wantNext: time.Date(2025, 6, 2, 17, 0, 0, 0, time.UTC),
+		},
+		"OutsideOpeningHours": {
+			park:     park(v1alpha1.ParkParameters{OpeningHours: hours}),
+			at:       time.Date(2025, 6, 2, 18, 0, 0, 0, time.UTC),
+			want:     false,
+			wantNext: time.Date(2025, 6, 3, 9, 0, 0, 0, time.UTC),
+		},
+		"ClosureDate": {
+			park:     park(v1alpha1.ParkParameters{OpeningHours: hours, ClosureDates: []v1alpha1.ClosureDate{"2025-12-25"}}),
+			at:       time.Date(2025, 12, 25, 12, 0, 0, 0, time.UTC),
+			want:     false,
+			wantNext: time.Date(2025, 12, 25, 17, 0, 0, 0, time.UTC),
+		},
+		"BeforeClosureDate": {
+			park:     park(v1alpha1.ParkParameters{ClosureDates: []v1alpha1.ClosureDate{"2025-12-25"}}),
+			at:       time.Date(2025, 12, 24, 12, 0, 0, 0, time.UTC),
+			want:     true,
+			wantNext: time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC),
+		},
+		"ClosureDateInTimeZone": {
+			park:     park(v1alpha1.ParkParameters{TimeZone: "America/Los_Angeles", ClosureDates: []v1alpha1.ClosureDate{"2025-12-25"}}),
+			at:       time.Date(2025, 12, 25, 3, 0, 0, 0, time.UTC),
+			want:     true,
+			wantNext: time.Date(2025, 12, 25, 8, 0, 0, 0, time.UTC),
+		},
+		"SpecialEvent": {
+			park:     park(v1alpha1.ParkParameters{OpeningHours: hours, SpecialEvents: []v1alpha1.SpecialEvent{halloween}}),
+			at:       time.Date(2025, 10, 31, 22, 0, 0, 0, time.UTC),
+			want:     true,
+			wantNext: time.Date(2025, 11, 1, 2, 0, 0, 0, time.UTC),
+		},
+		"SpecialEventOnClosureDate": {
+			park: park(v1alpha1.ParkParameters{
+				OpeningHours:  hours,
+				SpecialEvents: []v1alpha1.SpecialEvent{halloween},
+				ClosureDates:  []v1alpha1.ClosureDate{"2025-10-31"},
+			}),
+			at:       time.Date(2025, 10, 31, 21, 0, 0, 0, time.UTC),
+			want:     true,
+			wantNext: time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, next, err := ParkOpen(tc.park, tc.at)
+			if err != nil {
+				t.Fatalf("ParkOpen(...): %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("ParkOpen(...): want open %t, got %t", tc.want, got)
+			}
+			if !next.Equal(tc.wantNext) {
+				t.Errorf("ParkOpen(...): want next change at %s, got %s", tc.wantNext, next)
+			}
+		})
+	}
+
+	if _, _, err := ParkOpen(park(v1alpha1.ParkParameters{ClosureDates: []v1alpha1.ClosureDate{"Christmas"}}), time.Now()); err == nil {
+		t.Errorf("ParkOpen(...): want error for invalid closure date")
+	}
+}
+
+func TestUnderMaintenance(t *testing.T) {
+	inspection := v1alpha1.MaintenanceSchedule{
+		Recurring: []v1alpha1.WeeklyWindow{{Days: []v1alpha1.Weekday{"Tuesday"}, Start: "06:00", End: "09:00"}},
+	}
+	refurbishment := v1alpha1.MaintenanceSchedule{
+		OneOff: []v1alpha1.OneOffWindow{{
+			Start: metav1.NewTime(time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)),
+			End:   metav1.NewTime(time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC)),
+		}},
+	}
+
+	cases := map[string]struct {
+		schedules []v1alpha1.MaintenanceSchedule
+		at        time.Time
+		want      bool
+		wantNext  time.Time
+	}{
+		"NoSchedules": {
+			at: time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC),
+		},
+		"Recurring": {
+			schedules: []v1alpha1.MaintenanceSchedule{inspection},
+			at:        time.Date(2025, 6, 3, 7, 0, 0, 0, time.UTC),
+			want:      true,
+			wantNext:  time.Date(2025, 6, 3, 9, 0, 0, 0, time.UTC),
+		},
+		"OneOff": {
+			schedules: []v1alpha1.MaintenanceSchedule{refurbishment},
+			at:        time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC),
+			want:      true,
+			wantNext:  time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC),
+		},
+		"BetweenWindows": {
+			schedules: []v1alpha1.MaintenanceSchedule{inspection, refurbishment},
+			at:        time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+			want:      false,
+			wantNext:  time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC),
+		},
+		"Overlapping": {
+			schedules: []v1alpha1.MaintenanceSchedule{inspection, refurbishment},
+			at:        time.Date(2025, 6, 3, 7, 0, 0, 0, time.UTC),
+			want:      true,
+			wantNext:  time.Date(2025, 6, 3, 9, 0, 0, 0, time.UTC),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, next, err := UnderMaintenance(tc.schedules, tc.at)
+			if err != nil {
+				t.Fatalf("UnderMaintenance(...): %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("UnderMaintenance(...): want %t, got %t", tc.want, got)
+			}
+			if !next.Equal(tc.wantNext) {
+				t.Errorf("UnderMaintenance(...): want next change at %s, got %s", tc.wantNext, next)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	namespaced "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
+)
+
+// Namespaced Rides and RideOperators are admitted as the cluster-scoped Rides
+// and RideOperators they are managed as, so that they are defaulted and
+// validated the same way whatever their scope.
+
+// +kubebuilder:webhook:path=/validate-themepark-m-n3wscott-com-v1alpha1-ride,mutating=false,failurePolicy=fail,sideEffects=None,groups=themepark.m.n3wscott.com,resources=rides,verbs=update,versions=v1alpha1,name=vride-v1alpha1.m.kb.io,admissionReviewVersions=v1
+
+// A namespacedRideValidator validates namespaced Rides.
+type namespacedRideValidator struct {
+	cluster *rideValidator
+}
+
+var _ admission.CustomValidator = &namespacedRideValidator{}
+
+// ValidateCreate validates a new namespaced Ride.
+func (v *namespacedRideValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	r, ok := obj.(*namespaced.Ride)
+	if !ok {
+		return nil, errors.Errorf("expected a namespaced Ride, got %T", obj)
+	}
+	return v.cluster.ValidateCreate(ctx, r.ToCluster())
+}
+
+// ValidateUpdate validates a change to a namespaced Ride.
+func (v *namespacedRideValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*namespaced.Ride)
+	if !ok {
+		return nil, errors.Errorf("expected a namespaced Ride, got %T", oldObj)
+	}
+	r, ok := newObj.(*namespaced.Ride)
+	if !ok {
+		return nil, errors.Errorf("expected a namespaced Ride, got %T", newObj)
+	}
+	return v.cluster.ValidateUpdate(ctx, old.ToCluster(), r.ToCluster())
+}
+
+// ValidateDelete validates the deletion of a namespaced Ride.
+func (v *namespacedRideValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	r, ok := obj.(*namespaced.Ride)
+	if !ok {
+		return nil, errors.Errorf("expected a namespaced Ride, got %T", obj)
+	}
+	return v.cluster.ValidateDelete(ctx, r.ToCluster())
+}
+
+// +kubebuilder:webhook:path=/mutate-themepark-m-n3wscott-com-v1alpha1-rideoperator,mutating=true,failurePolicy=fail,sideEffects=None,groups=themepark.m.n3wscott.com,resources=rideoperators,verbs=create;update,versions=v1alpha1,name=mrideoperator-v1alpha1.m.kb.io,admissionReviewVersions=v1
+
+// A namespacedRideOperatorDefaulter defaults the fields of namespaced
+// RideOperators.
+type namespacedRideOperatorDefaulter struct {
+	cluster *rideOperatorDefaulter
+}
+
+var _ admission.CustomDefaulter = &namespacedRideOperatorDefaulter{}
+
+// Default the fields of a namespaced RideOperator.
+func (d *namespacedRideOperatorDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	o, ok := obj.(*namespaced.RideOperator)
+	if !ok {
+		return errors.Errorf("expected a namespaced RideOperator, got %T", obj)
+	}
+	ro := o.ToCluster()
+	if err := d.cluster.Default(ctx, ro); err != nil {
+		return err
+	}
+	o.FromCluster(ro)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-themepark-m-n3wscott-com-v1alpha1-rideoperator,mutating=false,failurePolicy=fail,sideEffects=None,groups=themepark.m.n3wscott.com,resources=rideoperators,verbs=create;update,versions=v1alpha1,name=vrideoperator-v1alpha1.m.kb.io,admissionReviewVersions=v1
+
+// A namespacedRideOperatorValidator validates namespaced RideOperators.
+type namespacedRideOperatorValidator struct {
+	cluster *rideOperatorValidator
+}
+
+var _ admission.CustomValidator = &namespacedRideOperatorValidator{}
+
+// ValidateCreate validates a new namespaced RideOperator.
+func (v *namespacedRideOperatorValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	o, ok := obj.(*namespaced.RideOperator)
+	if !ok {
+		return nil, errors.Errorf("expected a namespaced RideOperator, got %T", obj)
+	}
+	return v.cluster.ValidateCreate(ctx, o.ToCluster())
+}
+
+// ValidateUpdate validates a change to a namespaced RideOperator.
+func (v *namespacedRideOperatorValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*namespaced.RideOperator)
+	if !ok {
+		return nil, errors.Errorf("expected a namespaced RideOperator, got %T", oldObj)
+	}
+	o, ok := newObj.(*namespaced.RideOperator)
+	if !ok {
+		return nil, errors.Errorf("expected a namespaced RideOperator, got %T", newObj)
+	}
+	return v.cluster.ValidateUpdate(ctx, old.ToCluster(), o.ToCluster())
+}
+
+// ValidateDelete validates the deletion of a namespaced RideOperator.
+func (v *namespacedRideOperatorValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	o, ok := obj.(*namespaced.RideOperator)
+	if !ok {
+		return nil, errors.Errorf("expected a namespaced RideOperator, got %T", obj)
+	}
+	return v.cluster.ValidateDelete(ctx, o.ToCluster())
+}
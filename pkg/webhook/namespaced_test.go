@@ -0,0 +1,109 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	namespaced "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/pkg/reconciler/ride"
+)
+
+func TestNamespacedRideValidateUpdate(t *testing.T) {
+	cases := map[string]struct {
+		capacity *int
+		invalid  bool
+	}{
+		"Unchanged": {capacity: ptr.To(24)},
+		"Operating": {capacity: ptr.To(32), invalid: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			old := &namespaced.Ride{}
+			old.FromCluster(newRide(ptr.To(24), nil, ride.Operating()))
+			old.SetNamespace("team-a")
+			r := &namespaced.Ride{}
+			r.FromCluster(newRide(tc.capacity, nil, ride.Operating()))
+			r.SetNamespace("team-a")
+
+			v := &namespacedRideValidator{cluster: &rideValidator{}}
+			_, err := v.ValidateUpdate(context.Background(), old, r)
+			if got := kerrors.IsInvalid(err); got != tc.invalid {
+				t.Errorf("ValidateUpdate(...): want invalid %t, got %v", tc.invalid, err)
+			}
+		})
+	}
+}
+
+func TestNamespacedRideOperatorDefault(t *testing.T) {
+	o := newNamespacedOperator("team-a", &xpv1.TypedReference{Name: "coaster"})
+
+	d := &namespacedRideOperatorDefaulter{cluster: &rideOperatorDefaulter{}}
+	if err := d.Default(context.Background(), o); err != nil {
+		t.Fatalf("Default(...): %v", err)
+	}
+	want := &xpv1.TypedReference{
+		APIVersion: namespaced.GroupVersion.String(),
+		Kind:       namespaced.RideKind,
+		Name:       "coaster",
+	}
+	if diff := cmp.Diff(want, o.Spec.ForProvider.Ride); diff != "" {
+		t.Errorf("Default(...): -want ride, +got ride:\n%s", diff)
+	}
+	if o.GetNamespace() != "team-a" {
+		t.Errorf("Default(...): want namespace team-a, got %q", o.GetNamespace())
+	}
+}
+
+func TestNamespacedRideOperatorValidate(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := namespaced.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	kube := fake.NewClientBuilder().WithScheme(s).WithObjects(
+		&namespaced.Ride{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "coaster"}},
+	).Build()
+	v := &namespacedRideOperatorValidator{cluster: &rideOperatorValidator{kube: kube}}
+
+	cases := map[string]struct {
+		namespace string
+		ride      string
+		invalid   bool
+	}{
+		"Exists":           {namespace: "team-a", ride: "coaster"},
+		"NotExists":        {namespace: "team-a", ride: "phantom", invalid: true},
+		"InOtherNamespace": {namespace: "team-b", ride: "coaster", invalid: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ref := &xpv1.TypedReference{APIVersion: namespaced.GroupVersion.String(), Kind: namespaced.RideKind, Name: tc.ride}
+			_, err := v.ValidateCreate(context.Background(), newNamespacedOperator(tc.namespace, ref))
+			if got := kerrors.IsInvalid(err); got != tc.invalid {
+				t.Errorf("ValidateCreate(...): want invalid %t, got %v", tc.invalid, err)
+			}
+		})
+	}
+}
+
+// newNamespacedOperator returns a RideOperator in the supplied namespace
+// assigned to the supplied Ride.
+func newNamespacedOperator(namespace string, ride *xpv1.TypedReference) *namespaced.RideOperator {
+	return &namespaced.RideOperator{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "operator"},
+		Spec: namespaced.RideOperatorSpec{
+			ForProvider: namespaced.RideOperatorParameters{
+				Frequency: 10,
+				Ride:      ride,
+			},
+		},
+	}
+}
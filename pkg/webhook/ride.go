@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	namespaced "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/pkg/reconciler/ride"
+)
+
+// AnnotationKeyAllowCapacityChange allows the capacity of a Ride to be changed
+// while it is operating when set to "true".
+const AnnotationKeyAllowCapacityChange = "themepark.n3wscott.com/allow-capacity-change"
+
+// +kubebuilder:webhook:path=/validate-themepark-n3wscott-com-v1alpha1-ride,mutating=false,failurePolicy=fail,sideEffects=None,groups=themepark.n3wscott.com,resources=rides,verbs=update,versions=v1alpha1,name=vride-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// A rideValidator rejects changes to a Ride that would disrupt its riders.
+type rideValidator struct{}
+
+var _ admission.CustomValidator = &rideValidator{}
+
+// ValidateCreate allows every new Ride.
+func (v *rideValidator) ValidateCreate(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate rejects a change to the capacity of a Ride that is operating,
+// unless the change is explicitly allowed. Capacity that the provider late
+// initializes is not a change.
+func (v *rideValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*v1alpha1.Ride)
+	if !ok {
+		return nil, errors.Errorf("expected a Ride, got %T", oldObj)
+	}
+	r, ok := newObj.(*v1alpha1.Ride)
+	if !ok {
+		return nil, errors.Errorf("expected a Ride, got %T", newObj)
+	}
+
+	if old.Spec.ForProvider.Capacity == nil || ptr.Equal(old.Spec.ForProvider.Capacity, r.Spec.ForProvider.Capacity) {
+		return nil, nil
+	}
+	if old.GetCondition(ride.TypeOperational).Reason != ride.ReasonOperating {
+		return nil, nil
+	}
+	if r.GetAnnotations()[AnnotationKeyAllowCapacityChange] == "true" {
+		return nil, nil
+	}
+
+	gk := v1alpha1.RideGroupVersionKind.GroupKind()
+	if r.GetNamespace() != "" {
+		gk = namespaced.RideGroupVersionKind.GroupKind()
+	}
+	return nil, kerrors.NewInvalid(gk, r.GetName(), field.ErrorList{
+		field.Forbidden(field.NewPath("spec", "forProvider", "capacity"),
+			"cannot be changed while the Ride is operating, unless the "+AnnotationKeyAllowCapacityChange+` annotation is "true"`),
+	})
+}
+
+// ValidateDelete allows every Ride to be deleted. The provider blocks the
+// deletion of a Ride that operators are assigned to itself.
+func (v *rideValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/pkg/reconciler/ride"
+)
+
+func TestRideValidateUpdate(t *testing.T) {
+	cases := map[string]struct {
+		old     *v1alpha1.Ride
+		new     *v1alpha1.Ride
+		invalid bool
+	}{
+		"LateInitialized": {
+			old: newRide(nil, nil, ride.Operating()),
+			new: newRide(ptr.To(24), nil, ride.Operating()),
+		},
+		"Unchanged": {
+			old: newRide(ptr.To(24), nil, ride.Operating()),
+			new: newRide(ptr.To(24), nil, ride.Operating()),
+		},
+		"NotOperating": {
+			old: newRide(ptr.To(24), nil, ride.ShortStaffed()),
+			new: newRide(ptr.To(32), nil, ride.ShortStaffed()),
+		},
+		"Operating": {
+			old:     newRide(ptr.To(24), nil, ride.Operating()),
+			new:     newRide(ptr.To(32), nil, ride.Operating()),
+			invalid: true,
+		},
+		"OperatingUnset": {
+			old:     newRide(ptr.To(24), nil, ride.Operating()),
+			new:     newRide(nil, nil, ride.Operating()),
+			invalid: true,
+		},
+		"OperatingAllowed": {
+			old: newRide(ptr.To(24), nil, ride.Operating()),
+			new: newRide(ptr.To(32), map[string]string{AnnotationKeyAllowCapacityChange: "true"}, ride.Operating()),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := (&rideValidator{}).ValidateUpdate(context.Background(), tc.old, tc.new)
+			if got := kerrors.IsInvalid(err); got != tc.invalid {
+				t.Errorf("ValidateUpdate(...): want invalid %t, got %v", tc.invalid, err)
+			}
+		})
+	}
+}
+
+// newRide returns a rollercoaster with the supplied capacity, annotations and
+// conditions.
+func newRide(capacity *int, annotations map[string]string, c ...xpv1.Condition) *v1alpha1.Ride {
+	r := &v1alpha1.Ride{
+		ObjectMeta: metav1.ObjectMeta{Name: "coaster", Annotations: annotations},
+		Spec: v1alpha1.RideSpec{
+			ForProvider: v1alpha1.RideParameters{
+				Type:     "rollercoaster",
+				Capacity: capacity,
+			},
+		},
+	}
+	r.SetConditions(c...)
+	return r
+}
@@ -0,0 +1,123 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	namespaced "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+)
+
+// +kubebuilder:webhook:path=/mutate-themepark-n3wscott-com-v1alpha1-rideoperator,mutating=true,failurePolicy=fail,sideEffects=None,groups=themepark.n3wscott.com,resources=rideoperators,verbs=create;update,versions=v1alpha1,name=mrideoperator-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// A rideOperatorDefaulter defaults the fields of a RideOperator.
+type rideOperatorDefaulter struct{}
+
+var _ admission.CustomDefaulter = &rideOperatorDefaulter{}
+
+// Default the API version and kind of the Ride a RideOperator is assigned to,
+// so that only the name of the Ride need be given. A namespaced RideOperator's
+// Ride defaults to a namespaced Ride. The frequency is defaulted
+// by the CRD instead, which can tell an unset frequency from an explicit 0 that
+// must be rejected.
+func (d *rideOperatorDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	o, ok := obj.(*v1alpha1.RideOperator)
+	if !ok {
+		return errors.Errorf("expected a RideOperator, got %T", obj)
+	}
+
+	if ref := o.Spec.ForProvider.Ride; ref != nil {
+		gvk := v1alpha1.RideGroupVersionKind
+		if o.GetNamespace() != "" {
+			gvk = namespaced.RideGroupVersionKind
+		}
+		if ref.APIVersion == "" {
+			ref.APIVersion = gvk.GroupVersion().String()
+		}
+		if ref.Kind == "" {
+			ref.Kind = gvk.Kind
+		}
+	}
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-themepark-n3wscott-com-v1alpha1-rideoperator,mutating=false,failurePolicy=fail,sideEffects=None,groups=themepark.n3wscott.com,resources=rideoperators,verbs=create;update,versions=v1alpha1,name=vrideoperator-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// A rideOperatorValidator rejects RideOperators assigned to Rides that don't
+// exist.
+type rideOperatorValidator struct {
+	kube client.Reader
+}
+
+var _ admission.CustomValidator = &rideOperatorValidator{}
+
+// ValidateCreate rejects a RideOperator assigned to a Ride that doesn't exist.
+func (v *rideOperatorValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	o, ok := obj.(*v1alpha1.RideOperator)
+	if !ok {
+		return nil, errors.Errorf("expected a RideOperator, got %T", obj)
+	}
+	return nil, v.validateRide(ctx, o)
+}
+
+// ValidateUpdate rejects a RideOperator being reassigned to a Ride that
+// doesn't exist. An operator whose Ride was deleted after it was assigned
+// remains valid, so that it can still be updated and deleted.
+func (v *rideOperatorValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*v1alpha1.RideOperator)
+	if !ok {
+		return nil, errors.Errorf("expected a RideOperator, got %T", oldObj)
+	}
+	o, ok := newObj.(*v1alpha1.RideOperator)
+	if !ok {
+		return nil, errors.Errorf("expected a RideOperator, got %T", newObj)
+	}
+	if rideName(old) == rideName(o) {
+		return nil, nil
+	}
+	return nil, v.validateRide(ctx, o)
+}
+
+// ValidateDelete allows every RideOperator to be deleted.
+func (v *rideOperatorValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateRide returns an error if the supplied RideOperator is assigned to a
+// Ride that doesn't exist. A namespaced RideOperator must be assigned to a
+// namespaced Ride in its namespace. Operators that reference or select their
+// Ride are validated when the reference is resolved.
+func (v *rideOperatorValidator) validateRide(ctx context.Context, o *v1alpha1.RideOperator) error {
+	name := rideName(o)
+	if name == "" {
+		return nil
+	}
+
+	gk, r := v1alpha1.RideOperatorGroupVersionKind.GroupKind(), client.Object(&v1alpha1.Ride{})
+	if o.GetNamespace() != "" {
+		gk, r = namespaced.RideOperatorGroupVersionKind.GroupKind(), &namespaced.Ride{}
+	}
+	err := v.kube.Get(ctx, types.NamespacedName{Namespace: o.GetNamespace(), Name: name}, r)
+	if kerrors.IsNotFound(err) {
+		return kerrors.NewInvalid(gk, o.GetName(), field.ErrorList{
+			field.Invalid(field.NewPath("spec", "forProvider", "ride", "name"), name, "Ride does not exist"),
+		})
+	}
+	return errors.Wrapf(err, "cannot get Ride %q", name)
+}
+
+// rideName returns the name of the Ride the supplied RideOperator is assigned
+// to, if any.
+func rideName(o *v1alpha1.RideOperator) string {
+	if o.Spec.ForProvider.Ride == nil {
+		return ""
+	}
+	return o.Spec.ForProvider.Ride.Name
+}
@@ -0,0 +1,132 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+)
+
+func TestRideOperatorDefault(t *testing.T) {
+	cases := map[string]struct {
+		frequency     int
+		ride          *xpv1.TypedReference
+		wantFrequency int
+		wantRide      *xpv1.TypedReference
+	}{
+		"Unset": {
+			frequency:     10,
+			ride:          &xpv1.TypedReference{Name: "coaster"},
+			wantFrequency: 10,
+			wantRide: &xpv1.TypedReference{
+				APIVersion: v1alpha1.GroupVersion.String(),
+				Kind:       v1alpha1.RideKind,
+				Name:       "coaster",
+			},
+		},
+		"Set": {
+			frequency:     10,
+			ride:          &xpv1.TypedReference{APIVersion: "themepark.n3wscott.com/v1beta1", Kind: "Ride", Name: "coaster"},
+			wantFrequency: 10,
+			wantRide:      &xpv1.TypedReference{APIVersion: "themepark.n3wscott.com/v1beta1", Kind: "Ride", Name: "coaster"},
+		},
+		"NoRide": {
+			frequency:     10,
+			wantFrequency: 10,
+		},
+		"ZeroFrequency": {
+			// An explicit 0 is left for the CRD to reject.
+			ride:          &xpv1.TypedReference{APIVersion: "themepark.n3wscott.com/v1beta1", Kind: "Ride", Name: "coaster"},
+			wantFrequency: 0,
+			wantRide:      &xpv1.TypedReference{APIVersion: "themepark.n3wscott.com/v1beta1", Kind: "Ride", Name: "coaster"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o := newOperator(tc.ride)
+			o.Spec.ForProvider.Frequency = tc.frequency
+
+			if err := (&rideOperatorDefaulter{}).Default(context.Background(), o); err != nil {
+				t.Fatalf("Default(...): %v", err)
+			}
+			if o.Spec.ForProvider.Frequency != tc.wantFrequency {
+				t.Errorf("Default(...): want frequency %d, got %d", tc.wantFrequency, o.Spec.ForProvider.Frequency)
+			}
+			if diff := cmp.Diff(tc.wantRide, o.Spec.ForProvider.Ride); diff != "" {
+				t.Errorf("Default(...): -want ride, +got ride:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRideOperatorValidate(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	kube := fake.NewClientBuilder().WithScheme(s).WithObjects(&v1alpha1.Ride{ObjectMeta: metav1.ObjectMeta{Name: "coaster"}}).Build()
+	v := &rideOperatorValidator{kube: kube}
+
+	coaster := &xpv1.TypedReference{APIVersion: v1alpha1.GroupVersion.String(), Kind: v1alpha1.RideKind, Name: "coaster"}
+	phantom := &xpv1.TypedReference{APIVersion: v1alpha1.GroupVersion.String(), Kind: v1alpha1.RideKind, Name: "phantom"}
+
+	create := map[string]struct {
+		ride    *xpv1.TypedReference
+		invalid bool
+	}{
+		"Exists":     {ride: coaster},
+		"NotExists":  {ride: phantom, invalid: true},
+		"Unassigned": {},
+	}
+	for name, tc := range create {
+		t.Run("Create/"+name, func(t *testing.T) {
+			_, err := v.ValidateCreate(context.Background(), newOperator(tc.ride))
+			if got := kerrors.IsInvalid(err); got != tc.invalid {
+				t.Errorf("ValidateCreate(...): want invalid %t, got %v", tc.invalid, err)
+			}
+		})
+	}
+
+	update := map[string]struct {
+		old     *xpv1.TypedReference
+		new     *xpv1.TypedReference
+		invalid bool
+	}{
+		"Unchanged":           {old: coaster, new: coaster.DeepCopy()},
+		"RideDeleted":         {old: phantom, new: phantom.DeepCopy()},
+		"Assigned":            {new: coaster},
+		"AssignedNotExists":   {new: phantom, invalid: true},
+		"ReassignedNotExists": {old: coaster, new: phantom, invalid: true},
+		"Unassigned":          {old: phantom},
+	}
+	for name, tc := range update {
+		t.Run("Update/"+name, func(t *testing.T) {
+			_, err := v.ValidateUpdate(context.Background(), newOperator(tc.old), newOperator(tc.new))
+			if got := kerrors.IsInvalid(err); got != tc.invalid {
+				t.Errorf("ValidateUpdate(...): want invalid %t, got %v", tc.invalid, err)
+			}
+		})
+	}
+}
+
+// newOperator returns a RideOperator assigned to the supplied Ride.
+func newOperator(ride *xpv1.TypedReference) *v1alpha1.RideOperator {
+	return &v1alpha1.RideOperator{
+		ObjectMeta: metav1.ObjectMeta{Name: "operator"},
+		Spec: v1alpha1.RideOperatorSpec{
+			ForProvider: v1alpha1.RideOperatorParameters{
+				Frequency: 10,
+				Ride:      ride,
+			},
+		},
+	}
+}
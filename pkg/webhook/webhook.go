@@ -0,0 +1,49 @@
+// Package webhook serves the webhooks of the theme park API.
+package webhook
+
+import (
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	namespaced "github.com/n3wscott/theme-park-provider/api/namespaced/v1alpha1"
+	"github.com/n3wscott/theme-park-provider/api/v1alpha1"
+)
+
+// Setup adds the webhooks to the webhook server of the supplied manager. Rides
+// and RideOperators are converted between API versions by way of v1alpha1, so
+// every version of them must be in the manager's scheme. Admission requests
+// for any version are sent to the webhooks as v1alpha1. Namespaced Rides and
+// RideOperators share the webhooks of their cluster-scoped counterparts.
+func Setup(mgr ctrl.Manager) error {
+	rv := &rideValidator{}
+	rod := &rideOperatorDefaulter{}
+	rov := &rideOperatorValidator{kube: mgr.GetAPIReader()}
+
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.Ride{}).
+		WithValidator(rv).
+		Complete(); err != nil {
+		return errors.Wrap(err, "cannot setup Ride webhooks")
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.RideOperator{}).
+		WithDefaulter(rod).
+		WithValidator(rov).
+		Complete(); err != nil {
+		return errors.Wrap(err, "cannot setup RideOperator webhooks")
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&namespaced.Ride{}).
+		WithValidator(&namespacedRideValidator{cluster: rv}).
+		Complete(); err != nil {
+		return errors.Wrap(err, "cannot setup namespaced Ride webhooks")
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&namespaced.RideOperator{}).
+		WithDefaulter(&namespacedRideOperatorDefaulter{cluster: rod}).
+		WithValidator(&namespacedRideOperatorValidator{cluster: rov}).
+		Complete(); err != nil {
+		return errors.Wrap(err, "cannot setup namespaced RideOperator webhooks")
+	}
+	return nil
+}
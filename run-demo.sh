@@ -4,28 +4,34 @@
 echo "Building binaries..."
 make build-provider
 make build-reconciler
+make build-park
 
 # Function to clean up background processes on exit
 cleanup() {
   echo "Stopping processes..."
-  kill $PROVIDER_PID $RECONCILER_PID 2>/dev/null
+  kill $PARK_PID $PROVIDER_PID $RECONCILER_PID 2>/dev/null
   exit
 }
 
 # Register cleanup function to run on exit
 trap cleanup SIGINT SIGTERM
 
+# Start the park control system in the background
+echo "Starting Park control system in background..."
+./bin/park --address=:8090 &
+PARK_PID=$!
+
 # Start the provider in its own terminal or background
 if [ -x "$(command -v osascript)" ]; then
   # macOS approach
-  osascript -e "tell application \"Terminal\" to do script \"cd $(pwd) && export GRPC_ENDPOINT=:50051 && echo 'Starting Provider...' && ./bin/provider --config=${HOME}/.kube/config\""
+  osascript -e "tell application \"Terminal\" to do script \"cd $(pwd) && export GRPC_ENDPOINT=:50051 && echo 'Starting Provider...' && ./bin/provider --kubeconfig=${HOME}/.kube/config\""
 elif [ -x "$(command -v gnome-terminal)" ]; then
   # Linux with GNOME approach
-  gnome-terminal -- bash -c "cd $(pwd) && export GRPC_ENDPOINT=:50051 && echo 'Starting Provider...' && ./bin/provider --config=${HOME}/.kube/config; exec bash"
+  gnome-terminal -- bash -c "cd $(pwd) && export GRPC_ENDPOINT=:50051 && echo 'Starting Provider...' && ./bin/provider --kubeconfig=${HOME}/.kube/config; exec bash"
 else
   # Fallback approach - start in background
   echo "Starting Provider in background..."
-  GRPC_ENDPOINT=:50051 ./bin/provider --config=${HOME}/.kube/config &
+  GRPC_ENDPOINT=:50051 ./bin/provider --kubeconfig=${HOME}/.kube/config &
   PROVIDER_PID=$\!
 fi
 
@@ -23,6 +23,7 @@ echo "1. Start the provider and reconciler:"
 echo "   ./run-demo.sh"
 echo
 echo "2. In another terminal, create the example resources:"
+echo "   kubectl apply -f examples/providerconfig.yaml"
 echo "   kubectl apply -f examples/ride.yaml"
 echo "   kubectl apply -f examples/ride-operator.yaml"
 echo